@@ -1,4 +1,4 @@
-package main
+package sonarsweep
 
 import (
 	"github.com/bclarkx2/aoc"
@@ -17,7 +17,7 @@ func increases(depths []int) int {
 
 type solver struct{}
 
-func (s *solver) Solve1(input []string) (int, error) {
+func (s *solver) Solve1(input []string) (aoc.Answer, error) {
 	depths, err := aoc.Integers(input)
 	if err != nil {
 		return 0, err
@@ -26,7 +26,7 @@ func (s *solver) Solve1(input []string) (int, error) {
 	return increases(depths), nil
 }
 
-func (s *solver) Solve2(input []string) (int, error) {
+func (s *solver) Solve2(input []string) (aoc.Answer, error) {
 	depths, err := aoc.Integers(input)
 	if err != nil {
 		return 0, err
@@ -40,6 +40,6 @@ func (s *solver) Solve2(input []string) (int, error) {
 	return increases(windows), nil
 }
 
-func main() {
-	aoc.Run(aoc.ParseInputFile(), &solver{})
+func init() {
+	aoc.Register(2021, 1, "Sonar Sweep", &solver{})
 }
@@ -1,4 +1,4 @@
-package main
+package dive
 
 import (
 	"strconv"
@@ -9,7 +9,7 @@ import (
 
 type solver struct{}
 
-func (s *solver) Solve1(input []string) (int, error) {
+func (s *solver) Solve1(input []string) (aoc.Answer, error) {
 	x, y := 0, 0
 
 	for _, instruction := range input {
@@ -34,7 +34,7 @@ func (s *solver) Solve1(input []string) (int, error) {
 	return x * y, nil
 }
 
-func (s *solver) Solve2(input []string) (int, error) {
+func (s *solver) Solve2(input []string) (aoc.Answer, error) {
 	aim, x, y := 0, 0, 0
 
 	for _, instruction := range input {
@@ -60,6 +60,6 @@ func (s *solver) Solve2(input []string) (int, error) {
 	return x * y, nil
 }
 
-func main() {
-	aoc.Run(aoc.ParseInputFile(), &solver{})
+func init() {
+	aoc.Register(2021, 2, "Dive!", &solver{})
 }
@@ -1,4 +1,4 @@
-package main
+package binarydiagnostic
 
 import (
 	"github.com/bclarkx2/aoc"
@@ -36,7 +36,7 @@ func find(input []string, cmp func(zeroes, ones []string) []string) string {
 
 type solver struct{}
 
-func (s *solver) Solve1(input []string) (int, error) {
+func (s *solver) Solve1(input []string) (aoc.Answer, error) {
 	sums := make([]int, len(input[0]))
 	for _, i := range input {
 		for place, d := range i {
@@ -58,7 +58,7 @@ func (s *solver) Solve1(input []string) (int, error) {
 	return epsilon * gamma, nil
 }
 
-func (s *solver) Solve2(input []string) (int, error) {
+func (s *solver) Solve2(input []string) (aoc.Answer, error) {
 	mostStr := find(input, func(zeroes, ones []string) []string {
 		if len(ones) >= len(zeroes) {
 			return zeroes
@@ -87,6 +87,6 @@ func (s *solver) Solve2(input []string) (int, error) {
 	return most * least, nil
 }
 
-func main() {
-	aoc.Run(aoc.ParseInputFile(), &solver{})
+func init() {
+	aoc.Register(2021, 3, "Binary Diagnostic", &solver{})
 }
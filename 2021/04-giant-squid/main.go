@@ -1,4 +1,4 @@
-package main
+package giantsquid
 
 import (
 	"errors"
@@ -86,7 +86,22 @@ func (b *board) unmarkedSum() int {
 	return sum
 }
 
-func parse(lines []string) ([]int, map[int]board, error) {
+type game struct {
+	draws []int
+	grids [][][]int
+}
+
+// boards builds a fresh set of boards from the game's grids, since drawing
+// numbers marks them off.
+func (g game) boards() map[int]board {
+	boards := map[int]board{}
+	for id, grid := range g.grids {
+		boards[id+1] = newBoard(grid, id)
+	}
+	return boards
+}
+
+func parse(lines []string) (game, error) {
 	drawStrs := lines[0]
 	remaining := lines[2:]
 
@@ -97,8 +112,7 @@ func parse(lines []string) ([]int, map[int]board, error) {
 		}
 	}
 
-	boards := map[int]board{}
-	id := 0
+	var grids [][][]int
 	for start := 0; start < len(boardLines); start += 5 {
 		strLines := boardLines[start : start+5]
 
@@ -108,39 +122,41 @@ func parse(lines []string) ([]int, map[int]board, error) {
 			for _, numberStr := range numberStrs {
 				val, err := strconv.Atoi(numberStr)
 				if err != nil {
-					return nil, nil, err
+					return game{}, err
 				}
 
 				intLines[row] = append(intLines[row], val)
 			}
 		}
-		board := newBoard(intLines, id)
-		id++
-		boards[id] = board
-
+		grids = append(grids, intLines)
 	}
 
 	var draws []int
 	for _, drawStr := range strings.Split(drawStrs, ",") {
 		draw, err := strconv.Atoi(drawStr)
 		if err != nil {
-			return nil, nil, err
+			return game{}, err
 		}
 		draws = append(draws, draw)
 	}
 
-	return draws, boards, nil
+	return game{
+		draws: draws,
+		grids: grids,
+	}, nil
 }
 
 type solver struct{}
 
-func (s *solver) Solve1(input []string) (int, error) {
-	draws, boards, err := parse(input)
-	if err != nil {
-		return 0, err
-	}
+func (s *solver) Parse(input []string) (interface{}, error) {
+	return parse(input)
+}
+
+func (s *solver) SolveParsed1(parsed interface{}) (aoc.Answer, error) {
+	g := parsed.(game)
+	boards := g.boards()
 
-	for _, draw := range draws {
+	for _, draw := range g.draws {
 		for _, board := range boards {
 			if won := board.record(draw); won {
 				return board.unmarkedSum() * draw, nil
@@ -151,14 +167,12 @@ func (s *solver) Solve1(input []string) (int, error) {
 	return 0, errors.New("no answer found")
 }
 
-func (s *solver) Solve2(input []string) (int, error) {
-	draws, boards, err := parse(input)
-	if err != nil {
-		return 0, err
-	}
+func (s *solver) SolveParsed2(parsed interface{}) (aoc.Answer, error) {
+	g := parsed.(game)
+	boards := g.boards()
 
 	winners := map[int]bool{}
-	for _, draw := range draws {
+	for _, draw := range g.draws {
 		for id, board := range boards {
 			// can ignore boards that have already won
 			if _, won := winners[id]; won {
@@ -180,6 +194,6 @@ func (s *solver) Solve2(input []string) (int, error) {
 	return 0, errors.New("no answer found")
 }
 
-func main() {
-	aoc.Run(aoc.ParseInputFile(), &solver{})
+func init() {
+	aoc.Register(2021, 4, "Giant Squid", aoc.Parsed(&solver{}))
 }
@@ -1,4 +1,4 @@
-package main
+package hydrothermalventure
 
 import (
 	"regexp"
@@ -8,56 +8,11 @@ import (
 
 var lineExp = regexp.MustCompile(`(\d+),(\d+) -> (\d+),(\d+)`)
 
-type point struct {
-	x int
-	y int
-}
-
-func min(a, b int) int {
-	if a < b {
-		return a
-	}
-	return b
-}
-
-func max(a, b int) int {
-	if a > b {
-		return a
-	}
-	return b
-}
-
-func midpoints(begin, end point) []point {
-	stepsX := max(begin.x, end.x) - min(begin.x, end.x)
-	stepsY := max(begin.y, end.y) - min(begin.y, end.y)
-	steps := max(stepsX, stepsY)
-
-	deltaX, deltaY := 0, 0
-
-	if stepsX > 0 {
-		deltaX = (end.x - begin.x) / stepsX
-	}
-
-	if stepsY > 0 {
-		deltaY = (end.y - begin.y) / stepsY
-	}
-
-	var addrs []point
-	for step := 0; step <= steps; step++ {
-		addr := point{
-			x: begin.x + step*deltaX,
-			y: begin.y + step*deltaY,
-		}
-		addrs = append(addrs, addr)
-	}
-	return addrs
-}
-
 type solver struct{}
 
-func (s *solver) Solve1(input []string) (int, error) {
+func (s *solver) Solve1(input []string) (aoc.Answer, error) {
 
-	heatmap := map[point]int{}
+	heatmap := map[aoc.Point2]int{}
 	doubles := 0
 	for _, line := range input {
 		matches := lineExp.FindStringSubmatch(line)[1:]
@@ -66,20 +21,14 @@ func (s *solver) Solve1(input []string) (int, error) {
 			return 0, err
 		}
 
-		begin := point{nums[0], nums[1]}
-		end := point{nums[2], nums[3]}
+		vent := aoc.Segment{
+			From: aoc.Point2{X: nums[0], Y: nums[1]},
+			To:   aoc.Point2{X: nums[2], Y: nums[3]},
+		}
 
-		var addrs []point
-		if begin.x == end.x {
-			for y := min(begin.y, end.y); y <= max(begin.y, end.y); y++ {
-				addr := point{begin.x, y}
-				addrs = append(addrs, addr)
-			}
-		} else if begin.y == end.y {
-			for x := min(begin.x, end.x); x <= max(begin.x, end.x); x++ {
-				addr := point{x, begin.y}
-				addrs = append(addrs, addr)
-			}
+		var addrs []aoc.Point2
+		if vent.Horizontal() || vent.Vertical() {
+			addrs = vent.Points()
 		}
 
 		for _, addr := range addrs {
@@ -93,9 +42,9 @@ func (s *solver) Solve1(input []string) (int, error) {
 	return doubles, nil
 }
 
-func (s *solver) Solve2(input []string) (int, error) {
+func (s *solver) Solve2(input []string) (aoc.Answer, error) {
 
-	heatmap := map[point]int{}
+	heatmap := map[aoc.Point2]int{}
 	doubles := 0
 	for _, line := range input {
 		matches := lineExp.FindStringSubmatch(line)[1:]
@@ -104,11 +53,12 @@ func (s *solver) Solve2(input []string) (int, error) {
 			return 0, err
 		}
 
-		begin := point{nums[0], nums[1]}
-		end := point{nums[2], nums[3]}
+		vent := aoc.Segment{
+			From: aoc.Point2{X: nums[0], Y: nums[1]},
+			To:   aoc.Point2{X: nums[2], Y: nums[3]},
+		}
 
-		points := midpoints(begin, end)
-		for _, point := range points {
+		for _, point := range vent.Points() {
 			heatmap[point] += 1
 			if heatmap[point] == 2 {
 				doubles++
@@ -119,6 +69,6 @@ func (s *solver) Solve2(input []string) (int, error) {
 	return doubles, nil
 }
 
-func main() {
-	aoc.Run(aoc.ParseInputFile(), &solver{})
+func init() {
+	aoc.Register(2021, 5, "Hydrothermal Venture", &solver{})
 }
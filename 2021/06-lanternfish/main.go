@@ -1,6 +1,7 @@
-package main
+package lanternfish
 
 import (
+	"flag"
 	"strings"
 
 	"github.com/bclarkx2/aoc"
@@ -52,16 +53,22 @@ func calculate(input []string, days int) (int, error) {
 	return count, nil
 }
 
-type solver struct{}
+type solver struct {
+	days int
+}
+
+func (s *solver) Flags(fs *flag.FlagSet) {
+	fs.IntVar(&s.days, "days", 0, "Days to simulate in both parts, instead of 80 and 256")
+}
 
-func (s *solver) Solve1(input []string) (int, error) {
-	return calculate(input, 80)
+func (s *solver) Solve1(input []string) (aoc.Answer, error) {
+	return calculate(input, aoc.Or(s.days, 80))
 }
 
-func (s *solver) Solve2(input []string) (int, error) {
-	return calculate(input, 256)
+func (s *solver) Solve2(input []string) (aoc.Answer, error) {
+	return calculate(input, aoc.Or(s.days, 256))
 }
 
-func main() {
-	aoc.Run(aoc.ParseInputFile(), &solver{})
+func init() {
+	aoc.Register(2021, 6, "Lanternfish", &solver{})
 }
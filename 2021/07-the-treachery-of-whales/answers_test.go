@@ -0,0 +1,16 @@
+// Code generated by "aoc gentest 2021 7"; DO NOT EDIT.
+
+package thetreacheryofwhales
+
+import (
+	"testing"
+
+	"github.com/bclarkx2/aoc/aoctest"
+)
+
+func TestAnswers(t *testing.T) {
+	aoctest.RunPuzzle(t, 2021, 7, []aoctest.Case{
+		{Input: "example.txt", Part: 1, Want: "37"},
+		{Input: "example.txt", Part: 2, Want: "168"},
+	})
+}
@@ -1,4 +1,4 @@
-package main
+package thetreacheryofwhales
 
 import (
 	"strings"
@@ -38,14 +38,14 @@ func calculate(input []string, cost costFunc) (int, error) {
 
 type solver struct{}
 
-func (s *solver) Solve1(input []string) (int, error) {
+func (s *solver) Solve1(input []string) (aoc.Answer, error) {
 	cost := func(target, position int) int {
 		return aoc.AbsDiff(target, position)
 	}
 	return calculate(input, cost)
 }
 
-func (s *solver) Solve2(input []string) (int, error) {
+func (s *solver) Solve2(input []string) (aoc.Answer, error) {
 	cost := func(target, position int) int {
 		diff := float64(aoc.AbsDiff(target, position))
 		raw := ((diff + 1.0) / 2.0) * (0.0 + (diff)*1.0)
@@ -55,6 +55,6 @@ func (s *solver) Solve2(input []string) (int, error) {
 	return calculate(input, cost)
 }
 
-func main() {
-	aoc.Run(aoc.ParseInputFile(), &solver{})
+func init() {
+	aoc.Register(2021, 7, "The Treachery of Whales", &solver{})
 }
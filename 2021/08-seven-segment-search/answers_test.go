@@ -0,0 +1,18 @@
+// Code generated by "aoc gentest 2021 8"; DO NOT EDIT.
+
+package sevensegmentsearch
+
+import (
+	"testing"
+
+	"github.com/bclarkx2/aoc/aoctest"
+)
+
+func TestAnswers(t *testing.T) {
+	aoctest.RunPuzzle(t, 2021, 8, []aoctest.Case{
+		{Input: "example.txt", Part: 1, Want: "26"},
+		{Input: "example.txt", Part: 2, Want: "61229"},
+		{Input: "sample.txt", Part: 1, Want: "0"},
+		{Input: "sample.txt", Part: 2, Want: "5353"},
+	})
+}
@@ -1,4 +1,4 @@
-package main
+package sevensegmentsearch
 
 import (
 	"fmt"
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/bclarkx2/aoc"
+	"github.com/bclarkx2/aoc/ds"
 )
 
 type note struct {
@@ -35,31 +36,12 @@ var sevenSegmentValues = map[string]int{
 	"abcdfg":  9,
 }
 
-func update(counts map[string]int, digit string) {
-	for _, segRune := range digit {
-		seg := string(segRune)
-		counts[seg] = counts[seg] + 1
-	}
+func segments(digit string) ds.Set[string] {
+	return ds.NewSet(aoc.Characters(digit)...)
 }
 
-func segments(digit string) map[string]bool {
-	m := map[string]bool{}
-	for _, segRune := range digit {
-		seg := string(segRune)
-		m[seg] = true
-	}
-	return m
-}
-
-func count(digit string, subset map[string]bool) int {
-	c := 0
-	for _, segRune := range digit {
-		seg := string(segRune)
-		if _, ok := subset[seg]; ok {
-			c++
-		}
-	}
-	return c
+func count(digit string, subset ds.Set[string]) int {
+	return segments(digit).Intersection(subset).Len()
 }
 
 func value(digit string, mappings map[string]string) int {
@@ -77,7 +59,7 @@ func value(digit string, mappings map[string]string) int {
 
 type solver struct{}
 
-func (s *solver) Solve1(input []string) (int, error) {
+func (s *solver) Solve1(input []string) (aoc.Answer, error) {
 	var notes []note
 	for _, line := range input {
 		notes = append(notes, parse(line))
@@ -96,7 +78,7 @@ func (s *solver) Solve1(input []string) (int, error) {
 	return count, nil
 }
 
-func (s *solver) Solve2(input []string) (int, error) {
+func (s *solver) Solve2(input []string) (aoc.Answer, error) {
 	var notes []note
 	for _, line := range input {
 		notes = append(notes, parse(line))
@@ -106,26 +88,26 @@ func (s *solver) Solve2(input []string) (int, error) {
 	for _, note := range notes {
 
 		digits := map[string]int{}
-		segs := map[int]map[string]bool{}
+		segs := map[int]ds.Set[string]{}
 		fives := []string{}
 		sixes := []string{}
 
-		counts := map[string]int{}
+		counts := ds.NewCounter[string]()
 
 		for _, digit := range note.inputs {
 			switch len(digit) {
 			case 2:
 				digits[digit] = 1
 				segs[1] = segments(digit)
-				update(counts, digit)
+				counts.Add(aoc.Characters(digit)...)
 			case 3:
 				digits[digit] = 7
 				segs[7] = segments(digit)
-				update(counts, digit)
+				counts.Add(aoc.Characters(digit)...)
 			case 4:
 				digits[digit] = 4
 				segs[4] = segments(digit)
-				update(counts, digit)
+				counts.Add(aoc.Characters(digit)...)
 			case 5:
 				fives = append(fives, digit)
 			case 6:
@@ -133,7 +115,7 @@ func (s *solver) Solve2(input []string) (int, error) {
 			case 7:
 				digits[digit] = 8
 				segs[8] = segments(digit)
-				update(counts, digit)
+				counts.Add(aoc.Characters(digit)...)
 			}
 
 		}
@@ -142,17 +124,17 @@ func (s *solver) Solve2(input []string) (int, error) {
 		// 4: c, f
 		// 2: a, b, d
 		// 1: e, g
-		cOrF := map[string]bool{}
-		aOrBOrD := map[string]bool{}
-		eOrG := map[string]bool{}
+		cOrF := ds.NewSet[string]()
+		aOrBOrD := ds.NewSet[string]()
+		eOrG := ds.NewSet[string]()
 		for seg, count := range counts {
 			switch count {
 			case 1:
-				eOrG[seg] = true
+				eOrG.Add(seg)
 			case 2:
-				aOrBOrD[seg] = true
+				aOrBOrD.Add(seg)
 			case 4:
-				cOrF[seg] = true
+				cOrF.Add(seg)
 			}
 		}
 
@@ -161,19 +143,11 @@ func (s *solver) Solve2(input []string) (int, error) {
 
 		// a is the segment of input 7 that is not
 		// a segment in input 1
-		for seg := range segs[7] {
-			if _, ok := segs[1][seg]; !ok {
-				mapping["a"] = seg
-			}
+		for seg := range segs[7].Difference(segs[1]) {
+			mapping["a"] = seg
 		}
 
-		bOrD := map[string]bool{}
-		for seg := range aOrBOrD {
-			if seg == mapping["a"] {
-				continue
-			}
-			bOrD[seg] = true
-		}
+		bOrD := aOrBOrD.Difference(ds.NewSet(mapping["a"]))
 
 		// input 9 is the input with six segments that only
 		// contains either e or g
@@ -186,20 +160,15 @@ func (s *solver) Solve2(input []string) (int, error) {
 
 		// the segment that isn't c, f, b, d, or a in
 		// the nine input must be g
-		for seg := range segs[9] {
-			_, cf := cOrF[seg]
-			_, bd := bOrD[seg]
-			a := (seg == mapping["a"])
-			if !cf && !bd && !a {
-				mapping["g"] = seg
-			}
+		known := cOrF.Union(bOrD)
+		known.Add(mapping["a"])
+		for seg := range segs[9].Difference(known) {
+			mapping["g"] = seg
 		}
 
 		// e must be whichever is not g from eOrG
-		for seg := range eOrG {
-			if seg != mapping["g"] {
-				mapping["e"] = seg
-			}
+		for seg := range eOrG.Difference(ds.NewSet(mapping["g"])) {
+			mapping["e"] = seg
 		}
 
 		// identify 0 and 6
@@ -217,40 +186,26 @@ func (s *solver) Solve2(input []string) (int, error) {
 
 		// b is the one that's in the zero input
 		// that isn't c, f, a, e, or g
-		for segRune := range segs[0] {
-			seg := string(segRune)
-			isA := (seg == mapping["a"])
-			_, isCOrF := cOrF[seg]
-			_, isEOrG := eOrG[seg]
-			if !isA && !isCOrF && !isEOrG {
-				mapping["b"] = seg
-			}
+		known = cOrF.Union(eOrG)
+		known.Add(mapping["a"])
+		for seg := range segs[0].Difference(known) {
+			mapping["b"] = seg
 		}
 
 		// d is the member of bOrD that isn't b
-		for seg := range bOrD {
-			if seg != mapping["b"] {
-				mapping["d"] = seg
-			}
+		for seg := range bOrD.Difference(ds.NewSet(mapping["b"])) {
+			mapping["d"] = seg
 		}
 
-		// f is the member of input 6 that isn't
-		// a, b, c, d, e, or g
-		for segRune := range segs[6] {
-			seg := string(segRune)
-			_, isABD := aOrBOrD[seg]
-			_, isEG := eOrG[seg]
-			_, isCF := cOrF[seg]
-			if !isABD && !isEG && isCF {
-				mapping["f"] = seg
-			}
+		// f is the member of cOrF in input 6, which
+		// lacks c
+		for seg := range segs[6].Intersection(cOrF) {
+			mapping["f"] = seg
 		}
 
 		// c is the member of cOrF that isn't f
-		for seg := range cOrF {
-			if seg != mapping["f"] {
-				mapping["c"] = seg
-			}
+		for seg := range cOrF.Difference(ds.NewSet(mapping["f"])) {
+			mapping["c"] = seg
 		}
 
 		// invert the mapping to calculate the output value
@@ -281,6 +236,6 @@ func (s *solver) Solve2(input []string) (int, error) {
 	return sum, nil
 }
 
-func main() {
-	aoc.Run(aoc.ParseInputFile(), &solver{})
+func init() {
+	aoc.Register(2021, 8, "Seven Segment Search", &solver{})
 }
@@ -1,150 +1,95 @@
-package main
+package smokebasin
 
 import (
 	"github.com/bclarkx2/aoc"
 )
 
-type point struct {
-	height int
-	x      int
-	y      int
-
-	above *point
-	right *point
-	below *point
-	left  *point
+type heightmap struct {
+	*aoc.Grid[int]
 }
 
-func newPoints(input []string) [][]*point {
-	// Establish points
-	var points [][]*point
-	for _, line := range input {
-		row := []*point{}
-		for _, heightRune := range line {
-			point := &point{
-				height: int(heightRune - '0'),
-			}
-			row = append(row, point)
-		}
-		points = append(points, row)
-	}
-
-	// Set relationships
-	columns := len(points[0])
-	rows := len(points)
-	for y := 0; y < rows; y++ {
-		for x := 0; x < columns; x++ {
-			point := points[y][x]
-			point.x = x
-			point.y = y
-			if y > 0 {
-				point.above = points[y-1][x]
-			}
-			if x < columns-1 {
-				point.right = points[y][x+1]
-			}
-			if y < rows-1 {
-				point.below = points[y+1][x]
-			}
-			if x > 0 {
-				point.left = points[y][x-1]
-			}
-		}
-	}
-
-	return points
+func (h heightmap) risk(x, y int) int {
+	return h.At(x, y) + 1
 }
 
-func (p *point) Risk() int {
-	return p.height + 1
-}
-
-func (p *point) IsLowPoint() bool {
-	if p.above != nil && p.above.height <= p.height {
-		return false
-	}
-	if p.right != nil && p.right.height <= p.height {
-		return false
-	}
-	if p.below != nil && p.below.height <= p.height {
-		return false
-	}
-	if p.left != nil && p.left.height <= p.height {
-		return false
-	}
-	return true
+func (h heightmap) isLowPoint(x, y int) bool {
+	height := h.At(x, y)
+	low := true
+	h.Neighbors4(x, y, func(_, _ int, neighbor int) {
+		if neighbor <= height {
+			low = false
+		}
+	})
+	return low
 }
 
-func (p *point) BasinSize() int {
-	seen := map[*point]bool{}
-	p.basin(seen)
+func (h heightmap) basinSize(x, y int) int {
+	seen := map[[2]int]bool{}
+	h.basin(x, y, seen)
 	return len(seen)
 }
 
-func (p *point) basin(seen map[*point]bool) {
+func (h heightmap) basin(x, y int, seen map[[2]int]bool) {
+	height := h.At(x, y)
+
 	// if this is a peak, it does not increase the basin
-	if p.height == 9 {
+	if height == 9 {
 		return
 	}
 
 	// check if this point is already in the basin
-	if _, ok := seen[p]; ok {
+	if seen[[2]int{x, y}] {
 		return
 	}
 
 	// otherwise, always add this point to the basin
-	seen[p] = true
+	seen[[2]int{x, y}] = true
 
 	// add neighbor's basins if they are uphill
-	if p.above != nil && p.above.height >= p.height {
-		p.above.basin(seen)
-	}
-	if p.right != nil && p.right.height >= p.height {
-		p.right.basin(seen)
-	}
-	if p.below != nil && p.below.height >= p.height {
-		p.below.basin(seen)
-	}
-	if p.left != nil && p.left.height >= p.height {
-		p.left.basin(seen)
-	}
-
-	return
+	h.Neighbors4(x, y, func(nx, ny int, neighbor int) {
+		if neighbor >= height {
+			h.basin(nx, ny, seen)
+		}
+	})
 }
 
 type solver struct{}
 
-func (s *solver) Solve1(input []string) (int, error) {
-	points := newPoints(input)
+func (s *solver) Solve1(input []string) (aoc.Answer, error) {
+	grid, err := aoc.DigitGrid(input)
+	if err != nil {
+		return nil, err
+	}
+	h := heightmap{grid}
 
 	risk := 0
-	for _, row := range points {
-		for _, point := range row {
-			if point.IsLowPoint() {
-				risk += point.Risk()
-			}
+	h.Each(func(x, y int, _ int) {
+		if h.isLowPoint(x, y) {
+			risk += h.risk(x, y)
 		}
-	}
+	})
 
 	return risk, nil
 }
 
-func (s *solver) Solve2(input []string) (int, error) {
-	points := newPoints(input)
+func (s *solver) Solve2(input []string) (aoc.Answer, error) {
+	grid, err := aoc.DigitGrid(input)
+	if err != nil {
+		return nil, err
+	}
+	h := heightmap{grid}
 
 	var sizes []int
-	for _, row := range points {
-		for _, point := range row {
-			if point.IsLowPoint() {
-				sizes = append(sizes, point.BasinSize())
-			}
+	h.Each(func(x, y int, _ int) {
+		if h.isLowPoint(x, y) {
+			sizes = append(sizes, h.basinSize(x, y))
 		}
-	}
+	})
 
 	aoc.SortIntsDescending(sizes)
 	return sizes[0] * sizes[1] * sizes[2], nil
 }
 
-func main() {
-	aoc.Run(aoc.ParseInputFile(), &solver{})
+func init() {
+	aoc.Register(2021, 9, "Smoke Basin", &solver{})
 }
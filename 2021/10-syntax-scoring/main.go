@@ -1,26 +1,10 @@
-package main
+package syntaxscoring
 
 import (
 	"github.com/bclarkx2/aoc"
+	"github.com/bclarkx2/aoc/ds"
 )
 
-type stack []string
-
-func (s *stack) push(char string) {
-	*s = append(*s, char)
-}
-
-func (s *stack) pop() (string, bool) {
-	n := len(*s) - 1
-	if n < 0 {
-		return "", false
-	}
-
-	str := (*s)[n]
-	*s = (*s)[:n]
-	return str, true
-}
-
 var (
 	openings = []string{"(", "[", "{", "<"}
 	values1  = map[string]int{
@@ -53,17 +37,17 @@ func matches(opening, closing string) bool {
 
 type solver struct{}
 
-func (s *solver) Solve1(input []string) (int, error) {
+func (s *solver) Solve1(input []string) (aoc.Answer, error) {
 	score := 0
 	for _, line := range input {
-		s := stack{}
+		var s ds.Stack[string]
 		for _, char := range aoc.Characters(line) {
 			if aoc.ContainsStr(openings, char) {
-				s.push(char)
+				s.Push(char)
 				continue
 			}
 
-			corresponding, ok := s.pop()
+			corresponding, ok := s.Pop()
 			if !ok {
 				break
 			}
@@ -77,21 +61,21 @@ func (s *solver) Solve1(input []string) (int, error) {
 	return score, nil
 }
 
-func (s *solver) Solve2(input []string) (int, error) {
+func (s *solver) Solve2(input []string) (aoc.Answer, error) {
 
 	var scores []int
 lines:
 	for _, line := range input {
-		s := stack{}
+		var s ds.Stack[string]
 		for _, char := range aoc.Characters(line) {
 			if aoc.ContainsStr(openings, char) {
-				s.push(char)
+				s.Push(char)
 				continue
 			}
 
-			corresponding, ok := s.pop()
+			corresponding, ok := s.Pop()
 			if !ok {
-				s.push(corresponding)
+				s.Push(corresponding)
 				break
 			}
 			if !matches(corresponding, char) {
@@ -100,7 +84,7 @@ lines:
 		}
 
 		score := 0
-		for opening, ok := s.pop(); ok; opening, ok = s.pop() {
+		for opening, ok := s.Pop(); ok; opening, ok = s.Pop() {
 			score = score*5 + values2[opening]
 		}
 		scores = append(scores, score)
@@ -110,6 +94,6 @@ lines:
 	return scores[len(scores)/2], nil
 }
 
-func main() {
-	aoc.Run(aoc.ParseInputFile(), &solver{})
+func init() {
+	aoc.Register(2021, 10, "Syntax Scoring", &solver{})
 }
@@ -1,139 +1,71 @@
-package main
+package dumbooctopus
 
 import (
+	"context"
+
 	"github.com/bclarkx2/aoc"
 )
 
-type octopus struct {
-	energy int
-	x      int
-	y      int
-
-	neighbors []*octopus
+type octopi struct {
+	*aoc.Grid[int]
 }
 
-func (o *octopus) neighbor(n *octopus) {
-	o.neighbors = append(o.neighbors, n)
+func (o octopi) increment() {
+	o.Each(func(x, y int, energy int) {
+		o.Set(x, y, energy+1)
+	})
 }
 
-type octopi [][]*octopus
-
-func (o *octopi) all() []*octopus {
-	var a []*octopus
-	for _, row := range *o {
-		for _, octopus := range row {
-			a = append(a, octopus)
-		}
-	}
-	return a
-}
-
-func (o *octopi) increment() {
-	for _, octopus := range o.all() {
-		octopus.energy++
-	}
-}
-
-func (o *octopi) flash() int {
-	flashed := map[*octopus]bool{}
+func (o octopi) flash() int {
+	flashed := aoc.NewGrid[bool](o.Width(), o.Height())
+	numFlashed := 0
 
 	for {
-		numFlashed := 0
-		for _, octopus := range o.all() {
-			if _, ok := flashed[octopus]; ok {
-				continue
-			}
-
-			if octopus.energy <= 9 {
-				continue
+		flashedThisRound := 0
+		o.Each(func(x, y int, energy int) {
+			if flashed.At(x, y) || energy <= 9 {
+				return
 			}
 
-			flashed[octopus] = true
-			numFlashed++
+			flashed.Set(x, y, true)
+			flashedThisRound++
 
-			for _, n := range octopus.neighbors {
-				n.energy++
-			}
-		}
+			o.Neighbors8(x, y, func(nx, ny int, neighbor int) {
+				o.Set(nx, ny, neighbor+1)
+			})
+		})
 
-		if numFlashed == 0 {
+		if flashedThisRound == 0 {
 			break
 		}
+		numFlashed += flashedThisRound
 	}
 
-	for o := range flashed {
-		o.energy = 0
-	}
+	flashed.Each(func(x, y int, f bool) {
+		if f {
+			o.Set(x, y, 0)
+		}
+	})
 
-	return len(flashed)
+	return numFlashed
 }
 
-func (o *octopi) size() int {
-	return len(o.all())
+func (o octopi) size() int {
+	return o.Width() * o.Height()
 }
 
-func newOctopi(input []string) octopi {
-	// Establish octopi
-	var octopi [][]*octopus
-	for _, line := range input {
-		row := []*octopus{}
-		for _, energy := range aoc.IntCharacters(line) {
-			octopus := &octopus{
-				energy: energy,
-			}
-			row = append(row, octopus)
-		}
-		octopi = append(octopi, row)
-	}
-
-	// Set relationships
-	columns := len(octopi[0])
-	rows := len(octopi)
-	for y := 0; y < rows; y++ {
-		for x := 0; x < columns; x++ {
-			octopus := octopi[y][x]
-			octopus.x = x
-			octopus.y = y
-
-			belowTop := y > 0
-			beforeRight := x < columns-1
-			aboveBottom := y < rows-1
-			pastLeft := x > 0
-
-			if belowTop {
-				octopus.neighbor(octopi[y-1][x])
-			}
-			if beforeRight && belowTop {
-				octopus.neighbor(octopi[y-1][x+1])
-			}
-			if beforeRight {
-				octopus.neighbor(octopi[y][x+1])
-			}
-			if beforeRight && aboveBottom {
-				octopus.neighbor(octopi[y+1][x+1])
-			}
-			if aboveBottom {
-				octopus.neighbor(octopi[y+1][x])
-			}
-			if pastLeft && aboveBottom {
-				octopus.neighbor(octopi[y+1][x-1])
-			}
-			if pastLeft {
-				octopus.neighbor(octopi[y][x-1])
-			}
-			if pastLeft && belowTop {
-				octopus.neighbor(octopi[y-1][x-1])
-			}
-		}
-	}
-
-	return octopi
+func newOctopi(input []string) (octopi, error) {
+	grid, err := aoc.DigitGrid(input)
+	return octopi{grid}, err
 }
 
 type solver struct{}
 
-func (s *solver) Solve1(input []string) (int, error) {
-	octopi := newOctopi(input)
+func (s *solver) Solve1Context(ctx context.Context, input []string) (aoc.Answer, error) {
+	octopi, err := newOctopi(input)
+	if err != nil {
+		return nil, err
+	}
 
 	flashes := 0
 	for step := 1; step <= 100; step++ {
@@ -144,17 +76,23 @@ func (s *solver) Solve1(input []string) (int, error) {
 	return flashes, nil
 }
 
-func (s *solver) Solve2(input []string) (int, error) {
-	octopi := newOctopi(input)
+func (s *solver) Solve2Context(ctx context.Context, input []string) (aoc.Answer, error) {
+	octopi, err := newOctopi(input)
+	if err != nil {
+		return nil, err
+	}
 
 	var step int
 	for step = 0; octopi.flash() != octopi.size(); step++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		octopi.increment()
 	}
 
 	return step, nil
 }
 
-func main() {
-	aoc.Run(aoc.ParseInputFile(), &solver{})
+func init() {
+	aoc.Register(2021, 11, "Dumbo Octopus", aoc.Cancellable(&solver{}))
 }
@@ -0,0 +1,18 @@
+// Code generated by "aoc gentest 2021 12"; DO NOT EDIT.
+
+package passagepathing
+
+import (
+	"testing"
+
+	"github.com/bclarkx2/aoc/aoctest"
+)
+
+func TestAnswers(t *testing.T) {
+	aoctest.RunPuzzle(t, 2021, 12, []aoctest.Case{
+		{Input: "example.txt", Part: 1, Want: "19"},
+		{Input: "example.txt", Part: 2, Want: "103"},
+		{Input: "sample.txt", Part: 1, Want: "10"},
+		{Input: "sample.txt", Part: 2, Want: "36"},
+	})
+}
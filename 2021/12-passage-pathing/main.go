@@ -1,27 +1,29 @@
-package main
+package passagepathing
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
 	"github.com/bclarkx2/aoc"
+	"github.com/bclarkx2/aoc/ds"
 )
 
 type path struct {
 	links          []*cave
-	registry       map[string]int
+	registry       ds.Counter[string]
 	containsDouble bool
 }
 
 func newPath(links ...*cave) path {
 	p := path{
-		registry: map[string]int{},
+		registry: ds.NewCounter[string](),
 	}
 	for _, c := range links {
 		p.links = append(p.links, c)
 
 		count := p.registry[c.name]
-		p.registry[c.name] = count + 1
+		p.registry.Add(c.name)
 
 		if count > 0 && c.size == small {
 			p.containsDouble = true
@@ -55,22 +57,6 @@ func (p path) String() string {
 	return fmt.Sprintf("%t; %s", p.containsDouble, strings.Join(names, ","))
 }
 
-type queue []path
-
-func (q *queue) enqueue(p path) {
-	*q = append(*q, p)
-}
-
-func (q *queue) dequeue() (path, bool) {
-	if len(*q) <= 0 {
-		return path{}, false
-	}
-
-	p := (*q)[0]
-	*q = (*q)[1:]
-	return p, true
-}
-
 type size int
 
 const (
@@ -133,15 +119,18 @@ func (c *caves) addEdge(begin, end string) {
 	endNode.addNeighbor(beginNode)
 }
 
-func (c *caves) paths(doubleLimit int) []path {
-	queue := queue{
-		newPath(c.start),
-	}
+func (c *caves) paths(ctx context.Context, doubleLimit int) ([]path, error) {
+	var queue ds.Queue[path]
+	queue.Push(newPath(c.start))
 
 	var paths []path
 	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		// Pop the next path off the queue
-		path, ok := queue.dequeue()
+		path, ok := queue.Pop()
 		if !ok {
 			break
 		}
@@ -170,27 +159,33 @@ func (c *caves) paths(doubleLimit int) []path {
 				}
 			}
 			extended := path.extend(n)
-			queue.enqueue(extended)
+			queue.Push(extended)
 		}
 	}
 
-	return paths
+	return paths, nil
 }
 
 type solver struct{}
 
-func (s *solver) Solve1(input []string) (int, error) {
+func (s *solver) Solve1Context(ctx context.Context, input []string) (aoc.Answer, error) {
 	caves := newCaves(input)
-	paths := caves.paths(1)
+	paths, err := caves.paths(ctx, 1)
+	if err != nil {
+		return nil, err
+	}
 	return len(paths), nil
 }
 
-func (s *solver) Solve2(input []string) (int, error) {
+func (s *solver) Solve2Context(ctx context.Context, input []string) (aoc.Answer, error) {
 	caves := newCaves(input)
-	paths := caves.paths(2)
+	paths, err := caves.paths(ctx, 2)
+	if err != nil {
+		return nil, err
+	}
 	return len(paths), nil
 }
 
-func main() {
-	aoc.Run(aoc.ParseInputFile(), &solver{})
+func init() {
+	aoc.Register(2021, 12, "Passage Pathing", aoc.Cancellable(&solver{}))
 }
@@ -0,0 +1,16 @@
+// Code generated by "aoc gentest 2021 13"; DO NOT EDIT.
+
+package transparentorigami
+
+import (
+	"testing"
+
+	"github.com/bclarkx2/aoc/aoctest"
+)
+
+func TestAnswers(t *testing.T) {
+	aoctest.RunPuzzle(t, 2021, 13, []aoctest.Case{
+		{Input: "example.txt", Part: 1, Want: "17"},
+		{Input: "example.txt", Part: 2, Want: "#####\n#...#\n#...#\n#...#\n#####\n.....\n....."},
+	})
+}
@@ -1,7 +1,7 @@
-package main
+package transparentorigami
 
 import (
-	"fmt"
+	"context"
 	"regexp"
 	"strconv"
 	"strings"
@@ -21,18 +21,13 @@ type fold struct {
 	coordinate int
 }
 
-type point struct {
-	x int
-	y int
-}
-
 type sheet struct {
 	height int
 	width  int
 	points map[int]map[int]bool
 }
 
-func newSheet(points []point) sheet {
+func newSheet(points []aoc.Point2) sheet {
 	s := sheet{
 		points: map[int]map[int]bool{},
 	}
@@ -44,30 +39,30 @@ func newSheet(points []point) sheet {
 	return s
 }
 
-func (s *sheet) add(p point) {
-	row, ok := s.points[p.y]
+func (s *sheet) add(p aoc.Point2) {
+	row, ok := s.points[p.Y]
 	if !ok {
 		row = map[int]bool{}
 	}
-	row[p.x] = true
-	s.points[p.y] = row
-	if p.y > s.height {
-		s.height = p.y
+	row[p.X] = true
+	s.points[p.Y] = row
+	if p.Y > s.height {
+		s.height = p.Y
 	}
-	if p.x > s.width {
-		s.width = p.x
+	if p.X > s.width {
+		s.width = p.X
 	}
 }
 
-func (s *sheet) remove(p point) {
-	row, ok := s.points[p.y]
+func (s *sheet) remove(p aoc.Point2) {
+	row, ok := s.points[p.Y]
 	if !ok {
 		return
 	}
 
-	delete(row, p.x)
+	delete(row, p.X)
 	if len(row) == 0 {
-		delete(s.points, p.y)
+		delete(s.points, p.Y)
 	}
 }
 
@@ -87,8 +82,8 @@ func (s *sheet) fold(f fold) {
 				continue
 			}
 			for x := range row {
-				preimage := point{x, y}
-				image := point{x, y - 2*(y-f.coordinate)}
+				preimage := aoc.Point2{X: x, Y: y}
+				image := aoc.Point2{X: x, Y: y - 2*(y-f.coordinate)}
 				s.remove(preimage)
 				s.add(image)
 			}
@@ -100,8 +95,8 @@ func (s *sheet) fold(f fold) {
 				if x < f.coordinate {
 					continue
 				}
-				preimage := point{x, y}
-				image := point{x - 2*(x-f.coordinate), y}
+				preimage := aoc.Point2{X: x, Y: y}
+				image := aoc.Point2{X: x - 2*(x-f.coordinate), Y: y}
 				s.remove(preimage)
 				s.add(image)
 			}
@@ -135,7 +130,12 @@ func (s sheet) String() string {
 
 var foldRegex = regexp.MustCompile(`fold along (\w{1})=(\d+)`)
 
-func parse(input []string) ([]point, []fold, error) {
+type manual struct {
+	points []aoc.Point2
+	folds  []fold
+}
+
+func parse(input []string) (manual, error) {
 	var blankIdx int
 	for i, line := range input {
 		if line == "" {
@@ -146,15 +146,15 @@ func parse(input []string) ([]point, []fold, error) {
 	pointLines := input[:blankIdx]
 	foldLines := input[blankIdx+1:]
 
-	var points []point
+	var points []aoc.Point2
 	for _, line := range pointLines {
 		coords, err := aoc.Integers(strings.Split(line, ","))
 		if err != nil {
-			return nil, nil, err
+			return manual{}, err
 		}
-		p := point{
-			x: coords[0],
-			y: coords[1],
+		p := aoc.Point2{
+			X: coords[0],
+			Y: coords[1],
 		}
 		points = append(points, p)
 	}
@@ -165,7 +165,7 @@ func parse(input []string) ([]point, []fold, error) {
 		dir, coordStr := matches[1], matches[2]
 		coord, err := strconv.Atoi(coordStr)
 		if err != nil {
-			return nil, nil, err
+			return manual{}, err
 		}
 		f := fold{
 			direction:  direction(dir),
@@ -174,38 +174,47 @@ func parse(input []string) ([]point, []fold, error) {
 		folds = append(folds, f)
 	}
 
-	return points, folds, nil
+	return manual{
+		points: points,
+		folds:  folds,
+	}, nil
 }
 
 type solver struct{}
 
-func (s *solver) Solve1(input []string) (int, error) {
-	points, folds, err := parse(input)
-	if err != nil {
-		return 1, err
-	}
+func (s *solver) Parse(input []string) (interface{}, error) {
+	return parse(input)
+}
 
-	sheet := newSheet(points)
-	sheet.fold(folds[0])
+func (s *solver) SolveParsed1Context(ctx context.Context, parsed interface{}) (aoc.Answer, error) {
+	m := parsed.(manual)
+
+	sheet := newSheet(m.points)
+	sheet.fold(m.folds[0])
 
 	return sheet.size(), nil
 }
 
-func (s *solver) Solve2(input []string) (int, error) {
-	points, folds, err := parse(input)
-	if err != nil {
-		return 1, err
-	}
+func (s *solver) SolveParsed2Context(ctx context.Context, parsed interface{}) (aoc.Answer, error) {
+	m := parsed.(manual)
 
-	sheet := newSheet(points)
-	for _, f := range folds {
+	sheet := newSheet(m.points)
+	for _, f := range m.folds {
 		sheet.fold(f)
+		aoc.Logf(ctx, "fold along %s=%d leaves %d dots", f.direction, f.coordinate, sheet.size())
 	}
-	fmt.Printf("sheet:\n%s\n", sheet)
-
-	return 0, nil
+	// Not every sheet spells out a code, like the example's square, so
+	// fall back to the drawing itself.
+	drawing := sheet.String()
+	aoc.Logf(ctx, "sheet:\n%s", drawing)
+	code, err := aoc.OCR(drawing)
+	if err != nil {
+		aoc.Logf(ctx, "no code in the sheet: %s", err)
+		return drawing, nil
+	}
+	return code, nil
 }
 
-func main() {
-	aoc.Run(aoc.ParseInputFile(), &solver{})
+func init() {
+	aoc.Register(2021, 13, "Transparent Origami", aoc.CancellableParsed(&solver{}))
 }
@@ -0,0 +1,16 @@
+// Code generated by "aoc gentest 2021 14"; DO NOT EDIT.
+
+package extendedpolymerization
+
+import (
+	"testing"
+
+	"github.com/bclarkx2/aoc/aoctest"
+)
+
+func TestAnswers(t *testing.T) {
+	aoctest.RunPuzzle(t, 2021, 14, []aoctest.Case{
+		{Input: "example.txt", Part: 1, Want: "1588"},
+		{Input: "example.txt", Part: 2, Want: "2188189693529"},
+	})
+}
@@ -1,9 +1,11 @@
-package main
+package extendedpolymerization
 
 import (
+	"flag"
 	"strings"
 
 	"github.com/bclarkx2/aoc"
+	"github.com/bclarkx2/aoc/ds"
 )
 
 type pair struct {
@@ -16,25 +18,12 @@ type rule struct {
 	result string
 }
 
-type count map[string]int
-
-func newCount(elems ...string) count {
-	c := count{}
-	for _, elem := range elems {
-		c[elem] = c[elem] + 1
-	}
-	return c
-}
-
-func merge(c1, c2 count, intersect string) count {
-	c := count{}
-	for elem, num := range c1 {
-		c[elem] = c[elem] + num
-	}
-	for elem, num := range c2 {
-		c[elem] = c[elem] + num
-	}
-	c[intersect] = c[intersect] - 1
+// merge combines the counts of two chains that share the intersect
+// element, counting it only once.
+func merge(c1, c2 ds.Counter[string], intersect string) ds.Counter[string] {
+	c := c1.Clone()
+	c.Update(c2)
+	c[intersect]--
 	return c
 }
 
@@ -44,24 +33,21 @@ func solve(chain string, ruleList []rule, n int) int {
 		rules[rule.pair] = rule.result
 	}
 
-	characters := map[string]bool{}
-	for _, char := range aoc.Characters(chain) {
-		characters[char] = true
-	}
+	characters := ds.NewSet(aoc.Characters(chain)...)
 	for _, rule := range ruleList {
-		characters[rule.result] = true
+		characters.Add(rule.result)
 	}
 
-	table := map[pair][]count{}
+	table := map[pair][]ds.Counter[string]{}
 	for first := range characters {
 		for second := range characters {
-			row := make([]count, n+1)
+			row := make([]ds.Counter[string], n+1)
 			pair := pair{
 				first:  first,
 				second: second,
 			}
 
-			row[0] = newCount(first, second)
+			row[0] = ds.NewCounter(first, second)
 			table[pair] = row
 		}
 	}
@@ -85,7 +71,7 @@ func solve(chain string, ruleList []rule, n int) int {
 	}
 
 	chars := aoc.Characters(chain)
-	finalCount := newCount(chars[0])
+	finalCount := ds.NewCounter(chars[0])
 	for i := 0; i < len(chars)-1; i++ {
 		pair := pair{
 			first:  chars[i],
@@ -94,20 +80,17 @@ func solve(chain string, ruleList []rule, n int) int {
 		finalCount = merge(finalCount, table[pair][n], chars[i])
 	}
 
-	min, max := chars[0], chars[0]
-	for char, val := range finalCount {
-		if val < finalCount[min] {
-			min = char
-		}
-		if val > finalCount[max] {
-			max = char
-		}
-	}
+	_, least, _ := finalCount.LeastCommon()
+	_, most, _ := finalCount.MostCommon()
+	return most - least
+}
 
-	return finalCount[max] - finalCount[min]
+type manual struct {
+	chain string
+	rules []rule
 }
 
-func parse(input []string) (string, []rule) {
+func parse(input []string) manual {
 	chain := input[0]
 	ruleStrs := input[2:]
 
@@ -126,21 +109,34 @@ func parse(input []string) (string, []rule) {
 		rules = append(rules, r)
 	}
 
-	return chain, rules
+	return manual{
+		chain: chain,
+		rules: rules,
+	}
 }
 
-type solver struct{}
+type solver struct {
+	steps int
+}
+
+func (s *solver) Flags(fs *flag.FlagSet) {
+	fs.IntVar(&s.steps, "steps", 0, "Insertion steps in both parts, instead of 10 and 40")
+}
+
+func (s *solver) Parse(input []string) (interface{}, error) {
+	return parse(input), nil
+}
 
-func (s *solver) Solve1(input []string) (int, error) {
-	chain, rules := parse(input)
-	return solve(chain, rules, 10), nil
+func (s *solver) SolveParsed1(parsed interface{}) (aoc.Answer, error) {
+	m := parsed.(manual)
+	return solve(m.chain, m.rules, aoc.Or(s.steps, 10)), nil
 }
 
-func (s *solver) Solve2(input []string) (int, error) {
-	chain, rules := parse(input)
-	return solve(chain, rules, 40), nil
+func (s *solver) SolveParsed2(parsed interface{}) (aoc.Answer, error) {
+	m := parsed.(manual)
+	return solve(m.chain, m.rules, aoc.Or(s.steps, 40)), nil
 }
 
-func main() {
-	aoc.Run(aoc.ParseInputFile(), &solver{})
+func init() {
+	aoc.Register(2021, 14, "Extended Polymerization", aoc.Parsed(&solver{}))
 }
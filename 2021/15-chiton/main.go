@@ -1,198 +1,78 @@
-package main
+package chiton
 
 import (
-	"container/heap"
+	"context"
+	"flag"
 
 	"github.com/bclarkx2/aoc"
+	"github.com/bclarkx2/aoc/search"
 )
 
-type point struct {
-	x int
-	y int
-}
-
-type node struct {
-	p        point
-	distance int
-	index    int
-}
-
-type priorityQueue []*node
-
-func (pq priorityQueue) Len() int {
-	return len(pq)
-}
-
-func (pq priorityQueue) Less(i, j int) bool {
-	return pq[i].distance < pq[j].distance
-}
-
-func (pq priorityQueue) Swap(i, j int) {
-	pq[i], pq[j] = pq[j], pq[i]
-	pq[i].index = i
-	pq[j].index = j
-}
-
-func (pq *priorityQueue) Push(x interface{}) {
-	n := len(*pq)
-	item := x.(*node)
-	item.index = n
-	*pq = append(*pq, item)
-}
-
-func (pq *priorityQueue) Pop() interface{} {
-	old := *pq
-	n := len(old)
-	item := old[n-1]
-	old[n-1] = nil
-	item.index = -1
-	*pq = old[0 : n-1]
-	return item
-}
-
-func (pq *priorityQueue) update(node *node, distance int) {
-	node.distance = distance
-	heap.Fix(pq, node.index)
-}
-
-// frontier wraps a priority queue and also tracks the
-// memory location of each node by its x/y coordinates
-// for easy lookup.
-type frontier struct {
-	queue priorityQueue
-	nodes map[point]*node
-	size  int
-}
-
-func newFrontier(points map[point]int, size int) frontier {
-	infinity := 9*len(points) + 1
-	f := frontier{
-		queue: make(priorityQueue, len(points)),
-		nodes: map[point]*node{},
-		size:  size,
-	}
-
-	i := 0
-	for point := range points {
-		n := node{
-			p:        point,
-			distance: infinity,
-			index:    i,
-		}
-		f.queue[i] = &n
-		f.nodes[point] = &n
-		i++
-	}
-	heap.Init(&f.queue)
-
-	return f
-}
-
-func (f *frontier) update(p point, distance int) {
-	n := f.nodes[p]
-	f.queue.update(n, distance)
-}
-
-func (f *frontier) pop() *node {
-	return heap.Pop(&f.queue).(*node)
-}
-
-func (f *frontier) isEmpty() bool {
-	return f.queue.Len() <= 0
-}
-
-func (f *frontier) get(p point) *node {
-	return f.nodes[p]
-}
-
-func (f *frontier) neighbors(p point) []*node {
-	var nearby []*node
-	if p.x > 0 {
-		nearby = append(nearby, f.nodes[point{p.x - 1, p.y}])
-	}
-	if p.y > 0 {
-		nearby = append(nearby, f.nodes[point{p.x, p.y - 1}])
-	}
-	if p.x < f.size-1 {
-		nearby = append(nearby, f.nodes[point{p.x + 1, p.y}])
-	}
-	if p.y < f.size-1 {
-		nearby = append(nearby, f.nodes[point{p.x, p.y + 1}])
-	}
-	return nearby
-}
-
-func dijkstra(points map[point]int, size int) int {
-	begin := point{0, 0}
-	end := point{size - 1, size - 1}
-
-	frontier := newFrontier(points, size)
-	frontier.update(begin, 0)
-
-	for !frontier.isEmpty() {
-		current := frontier.pop()
-
-		for _, neighbor := range frontier.neighbors(current.p) {
-			proposed := current.distance + points[neighbor.p]
-			if proposed < neighbor.distance {
-				frontier.update(neighbor.p, proposed)
+// lowestRisk finds the total risk of the safest path from the top left
+// of the cave to the bottom right.
+func lowestRisk(ctx context.Context, risks *aoc.Grid[int]) (int, error) {
+	end := aoc.Point2{X: risks.Width() - 1, Y: risks.Height() - 1}
+
+	var edges []search.Edge[aoc.Point2]
+	risk, _, _ := search.Dijkstra(
+		aoc.Point2{},
+		func(p aoc.Point2) bool { return p == end },
+		func(p aoc.Point2) []search.Edge[aoc.Point2] {
+			// Once cancelled, stop exploring so the search runs dry.
+			if ctx.Err() != nil {
+				return nil
 			}
-		}
-
-		if current.p == end {
-			break
-		}
+			edges = edges[:0]
+			risks.Neighbors4(p.X, p.Y, func(x, y int, risk int) {
+				edges = append(edges, search.Edge[aoc.Point2]{To: aoc.Point2{X: x, Y: y}, Cost: risk})
+			})
+			return edges
+		},
+	)
+	if err := ctx.Err(); err != nil {
+		return 0, err
 	}
-
-	return frontier.get(end).distance
+	return risk, nil
 }
 
-func explode(points map[point]int, size int) map[point]int {
-	exploded := map[point]int{}
-	for p, risk := range points {
-		for xFactor := 0; xFactor < 5; xFactor++ {
-			for yFactor := 0; yFactor < 5; yFactor++ {
-				newPoint := point{
-					x: xFactor*size + p.x,
-					y: yFactor*size + p.y,
-				}
+// explode repeats the cave tiles times in each direction, with the risk
+// of each copy one higher than the copy above or to the left of it.
+func explode(risks *aoc.Grid[int], tiles int) *aoc.Grid[int] {
+	width, height := risks.Width(), risks.Height()
+	exploded := aoc.NewGrid[int](width*tiles, height*tiles)
+	risks.Each(func(x, y int, risk int) {
+		for xFactor := 0; xFactor < tiles; xFactor++ {
+			for yFactor := 0; yFactor < tiles; yFactor++ {
 				newRisk := (risk+xFactor+yFactor-1)%9 + 1
-				exploded[newPoint] = newRisk
+				exploded.Set(xFactor*width+x, yFactor*height+y, newRisk)
 			}
 		}
-	}
+	})
 	return exploded
 }
 
-type solver struct{}
+type solver struct {
+	tiles int
+}
 
-func (s *solver) Solve1(input []string) (int, error) {
-	points := map[point]int{}
-	size := len(input)
-	for y, line := range input {
-		ints := aoc.IntCharacters(line)
-		for x, risk := range ints {
-			points[point{x, y}] = risk
-		}
-	}
+func (s *solver) Flags(fs *flag.FlagSet) {
+	fs.IntVar(&s.tiles, "tiles", 0, "Times the cave repeats in each direction in part 2, instead of 5")
+}
 
-	return dijkstra(points, size), nil
+func (s *solver) Parse(input []string) (interface{}, error) {
+	return aoc.DigitGrid(input)
 }
 
-func (s *solver) Solve2(input []string) (int, error) {
-	points := map[point]int{}
-	size := len(input)
-	for y, line := range input {
-		ints := aoc.IntCharacters(line)
-		for x, risk := range ints {
-			points[point{x, y}] = risk
-		}
-	}
+func (s *solver) SolveParsed1Context(ctx context.Context, parsed interface{}) (aoc.Answer, error) {
+	risks := parsed.(*aoc.Grid[int])
+	return lowestRisk(ctx, risks)
+}
 
-	exploded := explode(points, size)
-	return dijkstra(exploded, size*5), nil
+func (s *solver) SolveParsed2Context(ctx context.Context, parsed interface{}) (aoc.Answer, error) {
+	risks := parsed.(*aoc.Grid[int])
+	return lowestRisk(ctx, explode(risks, aoc.Or(s.tiles, 5)))
 }
 
-func main() {
-	aoc.Run(aoc.ParseInputFile(), &solver{})
+func init() {
+	aoc.Register(2021, 15, "Chiton", aoc.CancellableParsed(&solver{}))
 }
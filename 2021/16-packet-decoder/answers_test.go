@@ -0,0 +1,32 @@
+// Code generated by "aoc gentest 2021 16"; DO NOT EDIT.
+
+package packetdecoder
+
+import (
+	"testing"
+
+	"github.com/bclarkx2/aoc/aoctest"
+)
+
+func TestAnswers(t *testing.T) {
+	aoctest.RunPuzzle(t, 2021, 16, []aoctest.Case{
+		{Input: "example.1.1.txt", Part: 1, Want: "16"},
+		{Input: "example.1.2.txt", Part: 1, Want: "12"},
+		{Input: "example.1.3.txt", Part: 1, Want: "23"},
+		{Input: "example.1.4.txt", Part: 1, Want: "31"},
+		{Input: "example.2.1.txt", Part: 2, Want: "3"},
+		{Input: "example.2.2.txt", Part: 2, Want: "54"},
+		{Input: "example.2.3.txt", Part: 2, Want: "7"},
+		{Input: "example.2.4.txt", Part: 2, Want: "9"},
+		{Input: "example.2.5.txt", Part: 2, Want: "1"},
+		{Input: "example.2.6.txt", Part: 2, Want: "0"},
+		{Input: "example.2.7.txt", Part: 2, Want: "0"},
+		{Input: "example.2.8.txt", Part: 2, Want: "1"},
+		{Input: "sample.length.txt", Part: 1, Want: "9"},
+		{Input: "sample.length.txt", Part: 2, Want: "1"},
+		{Input: "sample.literal.txt", Part: 1, Want: "6"},
+		{Input: "sample.literal.txt", Part: 2, Want: "2021"},
+		{Input: "sample.num.txt", Part: 1, Want: "14"},
+		{Input: "sample.num.txt", Part: 2, Want: "3"},
+	})
+}
@@ -1,4 +1,4 @@
-package main
+package packetdecoder
 
 import (
 	"math"
@@ -259,20 +259,20 @@ func parse(c *cursor) packet {
 
 type solver struct{}
 
-func (s *solver) Solve1(input []string) (int, error) {
+func (s *solver) Solve1(input []string) (aoc.Answer, error) {
 	bits := toBits(input[0])
 	c := newCursor(bits)
 	packet := parse(c)
 	return packet.Version(), nil
 }
 
-func (s *solver) Solve2(input []string) (int, error) {
+func (s *solver) Solve2(input []string) (aoc.Answer, error) {
 	bits := toBits(input[0])
 	c := newCursor(bits)
 	packet := parse(c)
 	return packet.Value(), nil
 }
 
-func main() {
-	aoc.Run(aoc.ParseInputFile(), &solver{})
+func init() {
+	aoc.Register(2021, 16, "Packet Decoder", &solver{})
 }
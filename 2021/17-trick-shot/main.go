@@ -1,4 +1,4 @@
-package main
+package trickshot
 
 import (
 	"math"
@@ -9,14 +9,24 @@ import (
 
 var promptRegex = regexp.MustCompile(`target area: x=([0-9-]+)..([0-9-]+), y=([0-9-]+)..([0-9-]+)`)
 
-func parse(prompt string) (int, int, int, int, error) {
+// target is the area the probe must land in, from its corner nearest the
+// origin in x and lowest in y to the opposite corner.
+type target struct {
+	min aoc.Point2
+	max aoc.Point2
+}
+
+func parse(prompt string) (target, error) {
 	matches := promptRegex.FindStringSubmatch(prompt)
 	vals, err := aoc.Integers(matches[1:])
 	if err != nil {
-		return 0, 0, 0, 0, err
+		return target{}, err
 	}
 
-	return vals[0], vals[1], vals[2], vals[3], nil
+	return target{
+		min: aoc.Point2{X: vals[0], Y: vals[2]},
+		max: aoc.Point2{X: vals[1], Y: vals[3]},
+	}, nil
 }
 
 func floor(f float64) int {
@@ -42,11 +52,6 @@ func velocityForX(xPosition int) float64 {
 	return 0.5*(math.Sqrt(8.0*x)+1.0) - 1.0
 }
 
-type velocity struct {
-	x int
-	y int
-}
-
 type nConstraints struct {
 	min int
 	max int
@@ -54,27 +59,27 @@ type nConstraints struct {
 
 type solver struct{}
 
-func (s *solver) Solve1(input []string) (int, error) {
-	_, _, yMin, _, err := parse(input[0])
+func (s *solver) Solve1(input []string) (aoc.Answer, error) {
+	t, err := parse(input[0])
 	if err != nil {
 		return 0, err
 	}
 
-	return yMin * (yMin + 1) / 2, nil
+	return t.min.Y * (t.min.Y + 1) / 2, nil
 }
 
-func (s *solver) Solve2(input []string) (int, error) {
-	xMin, xMax, yMin, yMax, err := parse(input[0])
+func (s *solver) Solve2(input []string) (aoc.Answer, error) {
+	t, err := parse(input[0])
 	if err != nil {
 		return 0, err
 	}
 
-	smallestX := ceil(velocityForX(xMin))
+	smallestX := ceil(velocityForX(t.min.X))
 
 	ns := map[int]nConstraints{}
-	for x := smallestX; x <= xMax; x++ {
-		nMin := stepsToX(xMin, x)
-		nMax := stepsToX(xMax, x)
+	for x := smallestX; x <= t.max.X; x++ {
+		nMin := stepsToX(t.min.X, x)
+		nMax := stepsToX(t.max.X, x)
 
 		if math.IsNaN(nMax) {
 			ns[x] = nConstraints{ceil(nMin), math.MaxInt}
@@ -83,18 +88,18 @@ func (s *solver) Solve2(input []string) (int, error) {
 		}
 	}
 
-	valid := map[velocity]bool{}
+	valid := map[aoc.Point2]bool{}
 	for x, nConstraint := range ns {
 		for n := nConstraint.min; n <= nConstraint.max; n++ {
-			smallestY := ceil(velocityForYAfterN(yMin, n))
-			largestY := floor(velocityForYAfterN(yMax, n))
+			smallestY := ceil(velocityForYAfterN(t.min.Y, n))
+			largestY := floor(velocityForYAfterN(t.max.Y, n))
 
-			if smallestY > aoc.Abs(yMin) {
+			if smallestY > aoc.Abs(t.min.Y) {
 				break
 			}
 
 			for y := smallestY; y <= largestY; y++ {
-				valid[velocity{x, y}] = true
+				valid[aoc.Point2{X: x, Y: y}] = true
 			}
 		}
 	}
@@ -102,6 +107,6 @@ func (s *solver) Solve2(input []string) (int, error) {
 	return len(valid), nil
 }
 
-func main() {
-	aoc.Run(aoc.ParseInputFile(), &solver{})
+func init() {
+	aoc.Register(2021, 17, "Trick Shot", &solver{})
 }
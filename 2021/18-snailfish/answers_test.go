@@ -0,0 +1,21 @@
+// Code generated by "aoc gentest 2021 18"; DO NOT EDIT.
+
+package snailfish
+
+import (
+	"testing"
+
+	"github.com/bclarkx2/aoc/aoctest"
+)
+
+func TestAnswers(t *testing.T) {
+	aoctest.RunPuzzle(t, 2021, 18, []aoctest.Case{
+		{Input: "example.txt", Part: 1, Want: "4140"},
+		{Input: "example.txt", Part: 2, Want: "3993"},
+		{Input: "sample.0.txt", Part: 1, Want: "1384"},
+		{Input: "sample.1.txt", Part: 1, Want: "445"},
+		{Input: "sample.2.txt", Part: 1, Want: "791"},
+		{Input: "sample.3.txt", Part: 1, Want: "1137"},
+		{Input: "sample.4.txt", Part: 1, Want: "3488"},
+	})
+}
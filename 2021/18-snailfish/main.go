@@ -1,12 +1,14 @@
-package main
+package snailfish
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math"
 	"strconv"
 
 	"github.com/bclarkx2/aoc"
+	"github.com/bclarkx2/aoc/ds"
 )
 
 type node interface {
@@ -199,25 +201,31 @@ func (n number) String() string {
 	return n.root.String()
 }
 
-func (n *number) Reduce() {
+// logf logs the steps of reducing a number.
+type logf func(format string, v ...interface{})
+
+func (n *number) Reduce(log logf) {
 	for {
 		if e := n.root.explodable(0); e != nil {
 			e.explode()
+			log("after explode: %s", n)
 		} else if s := n.root.splittable(); s != nil {
 			s.split()
+			log("after split:   %s", n)
 		} else {
 			break
 		}
 	}
 }
 
-func (n *number) Add(operand number) {
+func (n *number) Add(operand number, log logf) {
 	if n.root == nil {
 		n.root = operand.root
 		return
 	}
 	n.root = newPair(n.root, operand.root, nil)
-	n.Reduce()
+	log("after addition: %s", n)
+	n.Reduce(log)
 }
 
 func (n *number) Magnitude() int {
@@ -225,22 +233,22 @@ func (n *number) Magnitude() int {
 }
 
 func newNumber(str string) (number, error) {
-	ns := stack{}
+	var ns ds.Stack[node]
 	for _, char := range aoc.Characters(str) {
 		switch char {
 		case ",":
 			break
 		case "[":
 			node := pair{}
-			ns.push(&node)
+			ns.Push(&node)
 		case "]":
-			nodes, ok := ns.popN(3)
+			nodes, ok := popN(&ns, 3)
 			if !ok {
 				return number{}, errors.New("missing nodes")
 			}
 			right, left, parent := nodes[0], nodes[1], nodes[2].(*pair)
 			parent.addChildren(left, right)
-			ns.push(parent)
+			ns.Push(parent)
 		default:
 			value, err := strconv.Atoi(char)
 			if err != nil {
@@ -249,11 +257,11 @@ func newNumber(str string) (number, error) {
 			leaf := leaf{
 				val: value,
 			}
-			ns.push(&leaf)
+			ns.Push(&leaf)
 		}
 	}
 
-	root, ok := ns.pop()
+	root, ok := ns.Pop()
 	if !ok {
 		return number{}, errors.New("missing root")
 	}
@@ -263,28 +271,12 @@ func newNumber(str string) (number, error) {
 	}, nil
 }
 
-type stack []node
-
-func (s *stack) push(n node) {
-	*s = append(*s, n)
-}
-
-func (s *stack) pop() (node, bool) {
-	n := len(*s) - 1
-	if n < 0 {
-		return nil, false
-	}
-
-	node := (*s)[n]
-	*s = (*s)[:n]
-	return node, true
-}
-
-func (s *stack) popN(n int) ([]node, bool) {
+// popN pops n nodes off the stack, top first.
+func popN(s *ds.Stack[node], n int) ([]node, bool) {
 	nodes := make([]node, n)
 	ok := false
 	for i := 0; i < n; i++ {
-		nodes[i], ok = s.pop()
+		nodes[i], ok = s.Pop()
 		if !ok {
 			return nil, false
 		}
@@ -292,9 +284,16 @@ func (s *stack) popN(n int) ([]node, bool) {
 	return nodes, true
 }
 
+// logger returns a logf that logs through ctx.
+func logger(ctx context.Context) logf {
+	return func(format string, v ...interface{}) {
+		aoc.Logf(ctx, format, v...)
+	}
+}
+
 type solver struct{}
 
-func (s *solver) Solve1(input []string) (int, error) {
+func (s *solver) Solve1Context(ctx context.Context, input []string) (aoc.Answer, error) {
 	var sum number
 	for _, line := range input {
 		n, err := newNumber(line)
@@ -302,15 +301,18 @@ func (s *solver) Solve1(input []string) (int, error) {
 			return 0, err
 		}
 
-		sum.Add(n)
+		sum.Add(n, logger(ctx))
 	}
 
 	return sum.Magnitude(), nil
 }
 
-func (s *solver) Solve2(input []string) (int, error) {
+func (s *solver) Solve2Context(ctx context.Context, input []string) (aoc.Answer, error) {
 	var max int
 	for _, l1 := range input {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		for _, l2 := range input {
 			n1, err := newNumber(l1)
 			if err != nil {
@@ -322,7 +324,7 @@ func (s *solver) Solve2(input []string) (int, error) {
 				return 0, err
 			}
 
-			n1.Add(n2)
+			n1.Add(n2, logger(ctx))
 			mag := n1.Magnitude()
 			max = aoc.Max([]int{mag, max})
 		}
@@ -331,6 +333,6 @@ func (s *solver) Solve2(input []string) (int, error) {
 	return max, nil
 }
 
-func main() {
-	aoc.Run(aoc.ParseInputFile(), &solver{})
+func init() {
+	aoc.Register(2021, 18, "Snailfish", aoc.Cancellable(&solver{}))
 }
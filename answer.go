@@ -0,0 +1,48 @@
+package aoc
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Answer is the solution to one part of a puzzle. Solvers may return any
+// integer type, a string (including multi-line ASCII art), a *big.Int, or
+// any other fmt.Stringer.
+type Answer interface{}
+
+// FormatAnswer renders an answer as text. Answers are verified by comparing
+// this text with the answer recorded for the input.
+func FormatAnswer(a Answer) string {
+	if a == nil {
+		return ""
+	}
+	return fmt.Sprint(a)
+}
+
+// SingleLine renders an answer on one line, summarizing it if it spans
+// several, as ASCII art does. Tables use it to keep their rows intact.
+func SingleLine(a Answer) string {
+	s := FormatAnswer(a)
+	if n := strings.Count(s, "\n") + 1; n > 1 {
+		return fmt.Sprintf("[%d-line answer]", n)
+	}
+	return s
+}
+
+// unquoteAnswer reads an answer recorded in the answers file, where
+// answers spanning several lines are written as quoted Go strings.
+func unquoteAnswer(s string) (string, error) {
+	if strings.HasPrefix(s, `"`) {
+		return strconv.Unquote(s)
+	}
+	return s, nil
+}
+
+func indent(s, prefix string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = prefix + line
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,243 @@
+package aoc
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// answersFile is the name of the file, kept next to a day's inputs, that
+// records the known correct answer for each input file and part.
+//
+// Each non-blank line has the form:
+//
+//	<input file> <part> <answer>
+//
+// where the answer runs to the end of the line, and is written as a quoted
+// Go string if it spans several lines. Lines starting with '#' are ignored.
+//
+// Answers the website rejected are recorded too, by writing "!=" before the
+// answer, or "<" or ">" if the website said the right answer is lower or
+// higher than it.
+const answersFile = "answers"
+
+// Answers maps an input file name to the expected answer for each part.
+type Answers map[string]map[int]string
+
+// Rejections maps an input file name to the answers the website rejected
+// for each part.
+type Rejections map[string]map[int][]Rejection
+
+// Rejection is an answer the website said was wrong.
+type Rejection struct {
+	Answer string
+
+	// Bound is '<' if the right answer is lower, '>' if it is higher, and
+	// 0 if the website didn't say.
+	Bound byte
+}
+
+func (r Rejection) op() string {
+	if r.Bound == 0 {
+		return "!="
+	}
+	return string(r.Bound)
+}
+
+// answerLine is one entry in an answers file.
+type answerLine struct {
+	input  string
+	part   int
+	answer string
+
+	// op is empty for a correct answer, or the operator of a rejection.
+	op string
+}
+
+// LoadAnswers reads the answers recorded in dir. A directory without an
+// answers file has no known answers.
+func LoadAnswers(dir string) (Answers, error) {
+	lines, err := readAnswersFile(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	a := Answers{}
+	for _, l := range lines {
+		if l.op != "" {
+			continue
+		}
+		if a[l.input] == nil {
+			a[l.input] = map[int]string{}
+		}
+		a[l.input][l.part] = l.answer
+	}
+	return a, nil
+}
+
+// LoadRejections reads the rejected answers recorded in dir.
+func LoadRejections(dir string) (Rejections, error) {
+	lines, err := readAnswersFile(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	r := Rejections{}
+	for _, l := range lines {
+		if l.op == "" {
+			continue
+		}
+		var bound byte
+		if l.op != "!=" {
+			bound = l.op[0]
+		}
+		if r[l.input] == nil {
+			r[l.input] = map[int][]Rejection{}
+		}
+		r[l.input][l.part] = append(r[l.input][l.part], Rejection{l.answer, bound})
+	}
+	return r, nil
+}
+
+func readAnswersFile(dir string) ([]answerLine, error) {
+	path := filepath.Join(dir, answersFile)
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var lines []answerLine
+	scanner := bufio.NewScanner(f)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			return nil, fmt.Errorf("%s:%d: expected <input> <part> <answer>", path, lineNum)
+		}
+
+		name, partStr := fields[0], fields[1]
+		part, err := strconv.Atoi(partStr)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid part %q", path, lineNum, partStr)
+		}
+
+		rest := strings.TrimSpace(line[len(name):])
+		rest = strings.TrimSpace(rest[len(partStr):])
+
+		var op string
+		for _, o := range []string{"!=", "<", ">"} {
+			if len(fields) > 3 && fields[2] == o {
+				op = o
+				rest = strings.TrimSpace(rest[len(o):])
+				break
+			}
+		}
+
+		answer, err := unquoteAnswer(rest)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid answer %s: %w", path, lineNum, rest, err)
+		}
+
+		lines = append(lines, answerLine{name, part, answer, op})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return lines, nil
+}
+
+// Expected returns the answer recorded for one part of an input file.
+func (a Answers) Expected(inputFile string, part int) (string, bool) {
+	answer, ok := a[filepath.Base(inputFile)][part]
+	return answer, ok
+}
+
+// Check returns an error if the website is known to reject the answer to
+// one part of an input file, because it was rejected before or lies outside
+// the bounds of an earlier rejection.
+func (r Rejections) Check(inputFile string, part int, answer string) error {
+	n, err := strconv.Atoi(answer)
+	numeric := err == nil
+	for _, rejected := range r[filepath.Base(inputFile)][part] {
+		if answer == rejected.Answer {
+			return fmt.Errorf("%s was already rejected", answer)
+		}
+
+		bound, err := strconv.Atoi(rejected.Answer)
+		if !numeric || err != nil {
+			continue
+		}
+		if rejected.Bound == '<' && n >= bound {
+			return fmt.Errorf("%s is not lower than %d, which was too high", answer, bound)
+		}
+		if rejected.Bound == '>' && n <= bound {
+			return fmt.Errorf("%s is not higher than %d, which was too low", answer, bound)
+		}
+	}
+	return nil
+}
+
+// RecordAnswer adds the correct answer to one part of an input file to the
+// answers file in dir.
+func RecordAnswer(dir, inputFile string, part int, answer string) error {
+	return appendAnswer(dir, answerLine{filepath.Base(inputFile), part, answer, ""})
+}
+
+// RecordRejection adds an answer the website rejected to the answers file
+// in dir.
+func RecordRejection(dir, inputFile string, part int, r Rejection) error {
+	return appendAnswer(dir, answerLine{filepath.Base(inputFile), part, r.Answer, r.op()})
+}
+
+func appendAnswer(dir string, l answerLine) error {
+	f, err := os.OpenFile(filepath.Join(dir, answersFile), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	answer := l.answer
+	if strings.ContainsAny(answer, "\n\"") {
+		answer = strconv.Quote(answer)
+	}
+	fields := []string{l.input, strconv.Itoa(l.part), answer}
+	if l.op != "" {
+		fields = []string{l.input, strconv.Itoa(l.part), l.op, answer}
+	}
+
+	if _, err := fmt.Fprintln(f, strings.Join(fields, " ")); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+type status int
+
+const (
+	unknown status = iota
+	pass
+	fail
+)
+
+func (s status) String() string {
+	switch s {
+	case pass:
+		return "PASS"
+	case fail:
+		return "FAIL"
+	default:
+		return "UNKNOWN"
+	}
+}
@@ -1,91 +1,375 @@
 package aoc
 
 import (
-	"bufio"
+	"errors"
 	"flag"
 	"fmt"
-	"log"
+	"io"
 	"os"
+	"path/filepath"
+	"runtime"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 	"unicode"
-
-	"github.com/peterbourgon/ff/v3"
 )
 
 type Solver interface {
-	Solve1(input []string) (int, error)
-	Solve2(input []string) (int, error)
+	Solve1(input []string) (Answer, error)
+	Solve2(input []string) (Answer, error)
+}
+
+// Options configures how solvers are run.
+type Options struct {
+	Input string
+
+	// Part is the part to run, 1 or 2. When zero, both parts run.
+	Part int
+
+	// Unverified reports whether to skip checking a puzzle's answers
+	// against the answers file and recording its runs, for when its
+	// solver's own flags change the puzzle it solves. When nil, every
+	// puzzle is verified.
+	Unverified func(p Puzzle) bool
+
+	// Bench is the number of timed runs of each part, after Warmup untimed
+	// runs. When zero, each part runs once.
+	Bench  int
+	Warmup int
+
+	// Determinism is the number of times to run each part, checking that
+	// every run gives the same answer. Perturb varies the garbage collector
+	// between those runs.
+	Determinism int
+	Perturb     bool
+
+	// Timeout limits how long each part may run. When zero, parts run
+	// until they finish.
+	Timeout time.Duration
+
+	// Debug enables the logs that solvers write with Logf.
+	Debug bool
+
+	// Output is the format results are reported in: text, json, csv or
+	// markdown.
+	Output string
+
+	// History is the file that successful runs are recorded in. When
+	// empty, runs are not recorded. Neither are Debug runs, whose timings
+	// include their logging.
+	History string
+}
+
+func (o Options) unverified(p Puzzle) bool {
+	return o.Unverified != nil && o.Unverified(p)
+}
+
+// RegisterFlags registers the runner's flags on fs.
+func (o *Options) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.Input, "input", "puzzle.txt", "Input file, glob or directory")
+	fs.Var((*partFlag)(&o.Part), "part", "Part to run: 1, 2 or both")
+	fs.IntVar(&o.Bench, "bench", 0, "Run each part N times and report timing statistics")
+	fs.IntVar(&o.Warmup, "warmup", 0, "Untimed runs of each part before benchmarking")
+	fs.IntVar(&o.Determinism, "determinism", 0, "Run each part N times and fail it if the answers differ")
+	fs.BoolVar(&o.Perturb, "perturb", false, "Vary the garbage collector between -determinism runs")
+	fs.DurationVar(&o.Timeout, "timeout", 0, "Give up on a part after this long, e.g. 10s")
+	fs.BoolVar(&o.Debug, "debug", false, "Show the solvers' debug logs on standard error")
+	fs.BoolVar(&o.Debug, "v", false, "Shorthand for -debug")
+	fs.StringVar(&o.Output, "output", "text", "Result format: text, json, csv or markdown")
+	fs.StringVar(&o.History, "history", DefaultHistory, "File to record run timings in, or empty to disable")
 }
 
-func Run(inputFile string, solver Solver) {
-	f, err := os.Open(inputFile)
+// RunPuzzles runs each puzzle against opts.Input, resolved relative to the
+// puzzle's directory, and reports the results in the opts.Output format. It
+// returns an error if any input could not be read or any part failed,
+// whether by returning an error, panicking or not matching its expected
+// answer.
+func RunPuzzles(opts Options, puzzles ...Puzzle) error {
+	if opts.Input == Stdin && len(puzzles) > 1 {
+		return errors.New("standard input can only be read for one puzzle")
+	}
+
+	rep, err := newReporter(opts.Output, os.Stdout)
 	if err != nil {
-		fmt.Printf("Error opening file: %s", err)
-		return
+		return err
+	}
+
+	var commit string
+	if opts.History != "" {
+		commit = gitCommit()
+	}
+
+	var errs []string
+	for _, p := range puzzles {
+		executions, err := runPuzzle(opts, p)
+		if len(executions) > 0 {
+			if err := rep.report(p, executions); err != nil {
+				return err
+			}
+		}
+		printPanics(os.Stderr, executions)
+
+		// Timings under a solver's own flags aren't comparable with the
+		// puzzle as written, nor are those that include debug logging.
+		if opts.History != "" && !opts.unverified(p) && !opts.Debug {
+			records := newRecords(p, executions, commit, opts.mode(), time.Now())
+			if err := AppendHistory(opts.History, records); err != nil {
+				return fmt.Errorf("recording history: %w", err)
+			}
+		}
+
+		if err != nil {
+			if p.Title != "" {
+				err = fmt.Errorf("%s: %w", p, err)
+			}
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if err := rep.flush(); err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
+// Solve runs the puzzle's solver against opts.Input, as RunPuzzles does,
+// but returns the results rather than printing them.
+func Solve(opts Options, p Puzzle) ([]Result, error) {
+	executions, err := runPuzzle(opts, p)
+	return newResults(p, executions), err
+}
+
+// SolveReader runs the puzzle's solver against the input read from r. No
+// answers are known for such input, so its results are never verified.
+func SolveReader(opts Options, p Puzzle, r io.Reader) ([]Result, error) {
+	lines, err := ReadLinesFrom(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading input: %w", err)
+	}
+
+	executions := solveLines(opts, p.Solver, "", lines, Answers{})
+	return newResults(p, executions), failures(executions)
+}
+
+// runPuzzle runs both parts of the puzzle's solver against every input file
+// matched by opts.Input.
+func runPuzzle(opts Options, p Puzzle) ([]execution, error) {
+	input := p.resolve(opts.Input)
+	inputFiles, err := expandInput(input)
+	if err != nil {
+		err = fmt.Errorf("finding input files: %w", err)
+		return []execution{unread(input, err)}, err
+	}
+
+	var errs []string
+	var executions []execution
+	for _, inputFile := range inputFiles {
+		lines, err := ReadLines(inputFile)
+		if err != nil {
+			err = fmt.Errorf("reading input: %w", err)
+			executions = append(executions, unread(inputFile, err))
+			errs = append(errs, err.Error())
+			continue
+		}
+
+		answers := Answers{}
+		if inputFile != Stdin && !opts.unverified(p) {
+			answers, err = LoadAnswers(filepath.Dir(inputFile))
+			if err != nil {
+				err = fmt.Errorf("reading answers: %w", err)
+				executions = append(executions, unread(inputFile, err))
+				errs = append(errs, err.Error())
+				continue
+			}
+		}
+
+		executions = append(executions, solveLines(opts, p.Solver, inputFile, lines, answers)...)
+	}
+
+	if err := failures(executions); err != nil {
+		errs = append(errs, err.Error())
+	}
+
+	if len(errs) > 0 {
+		return executions, errors.New(strings.Join(errs, "; "))
+	}
+	return executions, nil
+}
+
+// solveLines runs both parts of a solver against one input and verifies
+// their answers.
+func solveLines(opts Options, solver Solver, inputFile string, lines []string, answers Answers) []execution {
+	hash := hashLines(lines)
+	run := withLogger(opts.runner(), opts, inputFile)
+	executions := solveInput(lines, solver, run, opts.Part)
+	for i := range executions {
+		e := &executions[i]
+		e.input = inputFile
+		e.inputHash = hash
+		if e.part > 0 {
+			e.verify(answers.Expected(inputFile, e.part))
+		}
 	}
-	defer f.Close()
+	return executions
+}
 
-	scanner := bufio.NewScanner(f)
-	var lines []string
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
+// failures returns an error counting the failed parts, if there were any.
+// Inputs that couldn't be read aren't counted, since they have errors of
+// their own.
+func failures(executions []execution) error {
+	failed, parts := 0, 0
+	for _, e := range executions {
+		if e.unread {
+			continue
+		}
+		if e.part > 0 {
+			parts++
+		}
+		if e.status == fail {
+			failed++
+		}
 	}
-	if err := scanner.Err(); err != nil {
-		fmt.Printf("Error reading file: %s", err)
-		return
+	if failed > 0 {
+		return fmt.Errorf("%d of %d parts failed", failed, parts)
 	}
+	return nil
+}
+
+// runner times a single part of a solver.
+type runner func(part int, f partFunc) execution
 
-	e1 := run(lines, solver.Solve1, "Solution 1")
-	e2 := run(lines, solver.Solve2, "Solution 2")
+func (o Options) runner() runner {
+	return func(part int, f partFunc) execution {
+		if o.Determinism > 1 {
+			return determinism(part, f, o.Determinism, o.Perturb, o.Timeout)
+		}
+		if o.Bench > 0 {
+			return benchmark(part, f, o.Bench, o.Warmup, o.Timeout)
+		}
+		return run(part, f, o.Timeout)
+	}
+}
 
-	fmt.Printf("\nInput: %s\n", inputFile)
-	fmt.Println(e1)
-	fmt.Println(e2)
+// mode names how the runner times each part, as recorded in the history.
+func (o Options) mode() string {
+	switch {
+	case o.Determinism > 1:
+		return "determinism"
+	case o.Bench > 0:
+		return "bench"
+	}
+	return ""
 }
 
-func run(input []string, f func([]string) (int, error), label string) execution {
+func run(part int, f partFunc, timeout time.Duration) execution {
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+
 	start := time.Now()
-	solution, err := f(input)
+	solution, err := call(f, timeout)
 	elapsed := time.Since(start)
+
+	runtime.ReadMemStats(&after)
 	return execution{
-		label:    label,
+		part:     part,
 		solution: solution,
 		err:      err,
 		elapsed:  elapsed,
+		allocs:   after.Mallocs - before.Mallocs,
+		bytes:    after.TotalAlloc - before.TotalAlloc,
 	}
 }
 
+// execution is the outcome of running one part of a solver, or of parsing
+// the input when part is 0. When unread is set, the input couldn't be read
+// or checked at all, and err says why.
 type execution struct {
-	label    string
-	solution int
-	err      error
-	elapsed  time.Duration
+	input     string
+	unread    bool
+	inputHash string
+	part      int
+	solution  Answer
+	err       error
+	elapsed   time.Duration
+	allocs    uint64
+	bytes     uint64
+	stats     *stats
+
+	status   status
+	expected string
 }
 
-func (e execution) String() string {
-	var output interface{} = e.solution
+// unread returns the failed execution for an input that couldn't be read.
+func unread(input string, err error) execution {
+	return execution{input: input, unread: true, err: err, status: fail}
+}
+
+// verify compares the solution against the expected answer, if one is
+// known. A part that returned an error fails either way.
+func (e *execution) verify(expected string, known bool) {
+	switch {
+	case e.err != nil:
+		e.status = fail
+	case !known:
+		e.status = unknown
+	case e.err == nil && FormatAnswer(e.solution) == expected:
+		e.status = pass
+	default:
+		e.status = fail
+	}
+	e.expected = expected
+}
+
+// output is the solution, or the error if the solver failed.
+func (e execution) output() interface{} {
 	if e.err != nil {
-		output = e.err
+		return e.err
 	}
-	return fmt.Sprintf(
-		"%s: %v (%vms)",
-		e.label,
-		output,
-		e.elapsed.Milliseconds(),
-	)
+	return e.solution
 }
 
-func ParseInputFile() string {
-	var inputFile string
-	flag.StringVar(&inputFile, "input", "puzzle.txt", "Input file")
+// timing describes how long the execution took.
+func (e execution) timing() string {
+	if e.stats != nil {
+		return e.stats.String()
+	}
+	return fmt.Sprintf("%vms", e.elapsed.Milliseconds())
+}
 
-	if err := ff.Parse(flag.CommandLine, os.Args[1:], ff.WithEnvVarNoPrefix()); err != nil {
-		log.Fatalf("Error parsing flags: %s", err)
+func (e execution) String() string {
+	if e.part == 0 {
+		if e.err != nil {
+			return fmt.Sprintf("Parse: %v (%s)", e.err, e.timing())
+		}
+		return fmt.Sprintf("Parse: (%s)", e.timing())
 	}
 
-	return inputFile
+	// Multi-line answers, like ASCII art, go on the lines that follow.
+	output := fmt.Sprint(e.output())
+	var art string
+	if strings.Contains(output, "\n") {
+		output, art = "see below", "\n"+indent(output, "  ")
+	}
+
+	s := fmt.Sprintf(
+		"Solution %d: %s (%s) %s",
+		e.part,
+		output,
+		e.timing(),
+		e.status,
+	)
+	if e.status == fail && e.expected != "" {
+		expected := e.expected
+		if strings.Contains(expected, "\n") {
+			expected = strconv.Quote(expected)
+		}
+		s += fmt.Sprintf(" (expected %s)", expected)
+	}
+	return s + art
 }
 
 func Integers(strs []string) ([]int, error) {
@@ -125,6 +409,24 @@ func AbsDiff(n, m int) int {
 	return n - m
 }
 
+// Or returns v if it is positive, or def otherwise. It suits solver flags
+// whose zero value means the puzzle's own setting.
+func Or(v, def int) int {
+	if v > 0 {
+		return v
+	}
+	return def
+}
+
+// GCD returns the greatest common divisor of a and b, which is never
+// negative. GCD(0, 0) is 0.
+func GCD(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return Abs(a)
+}
+
 func Min(ints []int) int {
 	if len(ints) < 1 {
 		panic("Min called on zero length array")
@@ -0,0 +1,72 @@
+// Package aoctest checks solvers against inputs with known answers.
+package aoctest
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/bclarkx2/aoc"
+)
+
+// Case is the answer expected from one part of a solver for an input file.
+type Case struct {
+	Input string
+	Part  int
+	Want  string
+}
+
+func (c Case) name() string {
+	return fmt.Sprintf("%s/part%d", c.Input, c.Part)
+}
+
+// Run checks solver against every case, each in its own subtest. Input
+// files are resolved relative to the test's working directory, which is
+// the package directory under go test.
+func Run(t *testing.T, solver aoc.Solver, cases []Case) {
+	t.Helper()
+
+	inputs := map[string][]string{}
+	for _, c := range cases {
+		c := c
+		t.Run(c.name(), func(t *testing.T) {
+			input, ok := inputs[c.Input]
+			if !ok {
+				var err error
+				input, err = aoc.ReadLines(c.Input)
+				if err != nil {
+					t.Fatalf("reading input: %s", err)
+				}
+				inputs[c.Input] = input
+			}
+
+			answer, err := aoc.SolvePart(solver, c.Part, input)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got := aoc.FormatAnswer(answer); got != c.Want {
+				t.Errorf("got %s, want %s", quote(got), quote(c.Want))
+			}
+		})
+	}
+}
+
+// RunPuzzle checks the solver registered for year and day against every
+// case.
+func RunPuzzle(t *testing.T, year, day int, cases []Case) {
+	t.Helper()
+
+	p, ok := aoc.Lookup(year, day)
+	if !ok {
+		t.Fatalf("no puzzle registered for %d day %d", year, day)
+	}
+	Run(t, p.Solver, cases)
+}
+
+// quote makes multi-line answers readable in failure messages.
+func quote(answer string) string {
+	if strconv.CanBackquote(answer) {
+		return answer
+	}
+	return strconv.Quote(answer)
+}
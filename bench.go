@@ -0,0 +1,89 @@
+package aoc
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"time"
+)
+
+// stats summarizes the repeated runs of a benchmarked part.
+type stats struct {
+	runs   int
+	min    time.Duration
+	median time.Duration
+	mean   time.Duration
+	p95    time.Duration
+
+	// allocs and bytes are averaged over the runs.
+	allocs uint64
+	bytes  uint64
+}
+
+// benchmark calls f warmup times, then times it for the given number of
+// runs. The returned execution holds the answer from the final run, with
+// its elapsed time, allocations and bytes replaced by the median and means.
+// A run that fails, such as by timing out, ends the benchmark early.
+func benchmark(part int, f partFunc, runs, warmup int, timeout time.Duration) execution {
+	for i := 0; i < warmup; i++ {
+		if _, err := call(f, timeout); err != nil {
+			return execution{part: part, err: err}
+		}
+	}
+	runtime.GC()
+
+	var e execution
+	var allocs, bytes uint64
+	durations := make([]time.Duration, runs)
+	for i := range durations {
+		e = run(part, f, timeout)
+		if e.err != nil {
+			return e
+		}
+		durations[i] = e.elapsed
+		allocs += e.allocs
+		bytes += e.bytes
+	}
+
+	sort.Slice(durations, func(i, j int) bool {
+		return durations[i] < durations[j]
+	})
+
+	var total time.Duration
+	for _, d := range durations {
+		total += d
+	}
+
+	s := stats{
+		runs:   runs,
+		min:    durations[0],
+		median: durations[runs/2],
+		mean:   total / time.Duration(runs),
+		p95:    durations[(runs*95+99)/100-1],
+		allocs: allocs / uint64(runs),
+		bytes:  bytes / uint64(runs),
+	}
+
+	e.stats = &s
+	e.elapsed = s.median
+	e.allocs = s.allocs
+	e.bytes = s.bytes
+	return e
+}
+
+func (s stats) String() string {
+	return fmt.Sprintf(
+		"n=%d min=%s median=%s mean=%s p95=%s allocs=%d bytes=%d",
+		s.runs,
+		micros(s.min),
+		micros(s.median),
+		micros(s.mean),
+		micros(s.p95),
+		s.allocs,
+		s.bytes,
+	)
+}
+
+func micros(d time.Duration) string {
+	return fmt.Sprintf("%.1fµs", float64(d)/float64(time.Microsecond))
+}
@@ -0,0 +1,210 @@
+// Package client talks to the Advent of Code website on behalf of a
+// logged-in user.
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultURL is the address of the Advent of Code website.
+const DefaultURL = "https://adventofcode.com"
+
+// DefaultInterval is the least time left between requests, so that running
+// a command across a whole year doesn't hammer the site.
+const DefaultInterval = 3 * time.Second
+
+const userAgent = "github.com/bclarkx2/aoc"
+
+// Client makes requests to the website using a session cookie.
+type Client struct {
+	// BaseURL is the address requests are sent to.
+	BaseURL string
+
+	// Session is the value of the session cookie set when logging in.
+	Session string
+
+	// Interval is the least time left between requests.
+	Interval time.Duration
+
+	HTTP *http.Client
+
+	mu   sync.Mutex
+	last time.Time
+}
+
+// New returns a client for the website that sends the given session cookie.
+func New(session string) *Client {
+	return &Client{
+		BaseURL:  DefaultURL,
+		Session:  session,
+		Interval: DefaultInterval,
+		HTTP:     http.DefaultClient,
+	}
+}
+
+var errNoSession = errors.New("no session cookie set")
+
+// StatusError is returned for a response other than 200 OK.
+type StatusError struct {
+	StatusCode int
+
+	// RetryAfter is how long the site asked us to wait before trying again,
+	// if it said.
+	RetryAfter time.Duration
+
+	Body string
+}
+
+func (e *StatusError) Error() string {
+	msg := fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	if e.RetryAfter > 0 {
+		msg += fmt.Sprintf(", retry after %s", e.RetryAfter)
+	}
+	if e.Body != "" {
+		msg += ": " + e.Body
+	}
+	return msg
+}
+
+// Input downloads the puzzle input for a day.
+func (c *Client) Input(ctx context.Context, year, day int) ([]byte, error) {
+	if c.Session == "" {
+		return nil, errNoSession
+	}
+	return c.do(ctx, http.MethodGet, fmt.Sprintf("/%d/day/%d/input", year, day), nil)
+}
+
+var titlePattern = regexp.MustCompile(`<h2>--- Day \d+: (.*?) ---</h2>`)
+
+// Title reads the title of a day's puzzle from its page. No session is
+// needed for this.
+func (c *Client) Title(ctx context.Context, year, day int) (string, error) {
+	page, err := c.do(ctx, http.MethodGet, fmt.Sprintf("/%d/day/%d", year, day), nil)
+	if err != nil {
+		return "", err
+	}
+	m := titlePattern.FindSubmatch(page)
+	if m == nil {
+		return "", fmt.Errorf("no title found on the page for %d day %d", year, day)
+	}
+	return html.UnescapeString(string(m[1])), nil
+}
+
+// FetchInput downloads the puzzle input for a day to path, unless the file
+// already exists. An empty file, such as the placeholder written by aoc new,
+// doesn't count. It reports whether the input was downloaded.
+func (c *Client) FetchInput(ctx context.Context, year, day int, path string) (bool, error) {
+	info, err := os.Stat(path)
+	switch {
+	case err == nil && info.Size() > 0:
+		return false, nil
+	case err != nil && !errors.Is(err, os.ErrNotExist):
+		return false, err
+	}
+
+	input, err := c.Input(ctx, year, day)
+	if err != nil {
+		return false, err
+	}
+
+	// Write to a temporary file first so that an interrupted download never
+	// leaves a partial input behind to be mistaken for a cached one.
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".input-*")
+	if err != nil {
+		return false, err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(input); err != nil {
+		tmp.Close()
+		return false, err
+	}
+	if err := tmp.Close(); err != nil {
+		return false, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// do sends a request, waiting first if the previous one was too recent, and
+// returns the body of a successful response.
+func (c *Client) do(ctx context.Context, method, path string, body io.Reader) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(c.BaseURL, "/")+path, body)
+	if err != nil {
+		return nil, err
+	}
+	if c.Session != "" {
+		req.AddCookie(&http.Cookie{Name: "session", Value: c.Session})
+	}
+	req.Header.Set("User-Agent", userAgent)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	if err := c.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	httpClient := c.HTTP
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		statusErr := &StatusError{
+			StatusCode: resp.StatusCode,
+			Body:       strings.TrimSpace(string(respBody)),
+		}
+		if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+			statusErr.RetryAfter = time.Duration(secs) * time.Second
+		}
+		return nil, statusErr
+	}
+
+	return respBody, nil
+}
+
+// wait blocks until Interval has passed since the last request.
+func (c *Client) wait(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.last.IsZero() {
+		if delay := c.Interval - time.Since(c.last); delay > 0 {
+			timer := time.NewTimer(delay)
+			defer timer.Stop()
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+
+	c.last = time.Now()
+	return nil
+}
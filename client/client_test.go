@@ -0,0 +1,206 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// site stands in for the website, serving one day's input to one session.
+type site struct {
+	requests int32
+}
+
+func (s *site) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	atomic.AddInt32(&s.requests, 1)
+
+	if r.URL.Path == "/2021/day/19" {
+		w.Write([]byte("<main>\n<article class=\"day-desc\"><h2>--- Day 19: Beacon Scanner ---</h2><p>As your probe drifted down...</p></article>\n</main>"))
+		return
+	}
+
+	cookie, err := r.Cookie("session")
+	if err != nil || cookie.Value != "secret" {
+		http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusBadRequest)
+		return
+	}
+
+	switch r.URL.Path {
+	case "/2021/day/19/input":
+		w.Write([]byte("--- scanner 0 ---\n404,-588,-901\n"))
+	case "/2021/day/20/input":
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func newTestClient(t *testing.T, session string) (*Client, *site) {
+	s := &site{}
+	server := httptest.NewServer(s)
+	t.Cleanup(server.Close)
+
+	c := New(session)
+	c.BaseURL = server.URL
+	c.HTTP = server.Client()
+	c.Interval = 0
+	return c, s
+}
+
+func TestInput(t *testing.T) {
+	c, _ := newTestClient(t, "secret")
+
+	input, err := c.Input(context.Background(), 2021, 19)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "--- scanner 0 ---\n404,-588,-901\n"; string(input) != want {
+		t.Errorf("got input %q, want %q", input, want)
+	}
+}
+
+func TestTitle(t *testing.T) {
+	c, _ := newTestClient(t, "")
+
+	title, err := c.Title(context.Background(), 2021, 19)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Beacon Scanner"; title != want {
+		t.Errorf("got title %q, want %q", title, want)
+	}
+}
+
+func TestInputErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		session    string
+		day        int
+		status     int
+		retryAfter time.Duration
+	}{
+		{"logged out", "wrong", 19, http.StatusBadRequest, 0},
+		{"locked", "secret", 25, http.StatusNotFound, 0},
+		{"rate limited", "secret", 20, http.StatusTooManyRequests, time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := newTestClient(t, tt.session)
+
+			_, err := c.Input(context.Background(), 2021, tt.day)
+			var statusErr *StatusError
+			if !errors.As(err, &statusErr) {
+				t.Fatalf("got error %v, want a StatusError", err)
+			}
+			if statusErr.StatusCode != tt.status {
+				t.Errorf("got status %d, want %d", statusErr.StatusCode, tt.status)
+			}
+			if statusErr.RetryAfter != tt.retryAfter {
+				t.Errorf("got retry after %s, want %s", statusErr.RetryAfter, tt.retryAfter)
+			}
+		})
+	}
+}
+
+func TestInputWithoutSession(t *testing.T) {
+	c, s := newTestClient(t, "")
+
+	if _, err := c.Input(context.Background(), 2021, 19); err == nil {
+		t.Error("got no error without a session")
+	}
+	if n := atomic.LoadInt32(&s.requests); n != 0 {
+		t.Errorf("made %d requests without a session", n)
+	}
+}
+
+func TestFetchInputCaches(t *testing.T) {
+	c, s := newTestClient(t, "secret")
+	path := filepath.Join(t.TempDir(), "puzzle.txt")
+
+	for i, wantFetched := range []bool{true, false} {
+		fetched, err := c.FetchInput(context.Background(), 2021, 19, path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fetched != wantFetched {
+			t.Errorf("fetch %d: got fetched %t, want %t", i+1, fetched, wantFetched)
+		}
+	}
+	if n := atomic.LoadInt32(&s.requests); n != 1 {
+		t.Errorf("made %d requests, want 1", n)
+	}
+
+	input, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(input) == 0 {
+		t.Error("cached input is empty")
+	}
+}
+
+func TestFetchInputReplacesEmptyFile(t *testing.T) {
+	c, s := newTestClient(t, "secret")
+	path := filepath.Join(t.TempDir(), "puzzle.txt")
+	if err := os.WriteFile(path, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	fetched, err := c.FetchInput(context.Background(), 2021, 19, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !fetched {
+		t.Error("got fetched false for an empty file")
+	}
+	if n := atomic.LoadInt32(&s.requests); n != 1 {
+		t.Errorf("made %d requests, want 1", n)
+	}
+
+	input, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(input) == 0 {
+		t.Error("input is still empty")
+	}
+}
+
+func TestFetchInputFailureLeavesNoFile(t *testing.T) {
+	c, _ := newTestClient(t, "secret")
+	dir := t.TempDir()
+
+	if _, err := c.FetchInput(context.Background(), 2021, 25, filepath.Join(dir, "puzzle.txt")); err == nil {
+		t.Fatal("got no error for a locked day")
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("left %d files behind", len(entries))
+	}
+}
+
+func TestInterval(t *testing.T) {
+	c, _ := newTestClient(t, "secret")
+	c.Interval = 50 * time.Millisecond
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := c.Input(context.Background(), 2021, 19); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 2*c.Interval {
+		t.Errorf("three requests took %s, want at least %s", elapsed, 2*c.Interval)
+	}
+}
@@ -0,0 +1,126 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Outcome is what the website made of a submitted answer.
+type Outcome int
+
+const (
+	// Unrecognized means the response didn't match any known message.
+	Unrecognized Outcome = iota
+	Correct
+	Wrong
+	TooHigh
+	TooLow
+	// TooSoon means the answer was not checked, because the last one was
+	// submitted too recently.
+	TooSoon
+	// AlreadySolved means the part has already been solved, or isn't
+	// unlocked yet.
+	AlreadySolved
+)
+
+func (o Outcome) String() string {
+	switch o {
+	case Correct:
+		return "correct"
+	case Wrong:
+		return "wrong"
+	case TooHigh:
+		return "too high"
+	case TooLow:
+		return "too low"
+	case TooSoon:
+		return "too soon"
+	case AlreadySolved:
+		return "already solved"
+	default:
+		return "unrecognized"
+	}
+}
+
+// Verdict is the website's response to a submitted answer.
+type Verdict struct {
+	Outcome Outcome
+
+	// Wait is how long to wait before submitting another answer, if the
+	// response said.
+	Wait time.Duration
+
+	// Message is the text of the response.
+	Message string
+}
+
+// Submit posts an answer to one part of a day's puzzle.
+func (c *Client) Submit(ctx context.Context, year, day, part int, answer string) (Verdict, error) {
+	if c.Session == "" {
+		return Verdict{}, errNoSession
+	}
+	form := url.Values{
+		"level":  {strconv.Itoa(part)},
+		"answer": {answer},
+	}
+	body, err := c.do(ctx, http.MethodPost, fmt.Sprintf("/%d/day/%d/answer", year, day), strings.NewReader(form.Encode()))
+	if err != nil {
+		return Verdict{}, err
+	}
+	return parseVerdict(string(body)), nil
+}
+
+var (
+	articlePattern = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	tagPattern     = regexp.MustCompile(`<[^>]*>`)
+	spacePattern   = regexp.MustCompile(`\s+`)
+	leftPattern    = regexp.MustCompile(`You have ([0-9hms ]+) left to wait`)
+	waitPattern    = regexp.MustCompile(`(?i)wait (one|\d+) minutes?`)
+)
+
+// parseVerdict reads the message in the response to a submitted answer.
+func parseVerdict(page string) Verdict {
+	text := page
+	if m := articlePattern.FindStringSubmatch(page); m != nil {
+		text = m[1]
+	}
+	text = html.UnescapeString(tagPattern.ReplaceAllString(text, ""))
+	text = strings.TrimSpace(spacePattern.ReplaceAllString(text, " "))
+
+	v := Verdict{Message: text}
+	switch {
+	case strings.Contains(text, "That's the right answer"):
+		v.Outcome = Correct
+	case strings.Contains(text, "your answer is too high"):
+		v.Outcome = TooHigh
+	case strings.Contains(text, "your answer is too low"):
+		v.Outcome = TooLow
+	case strings.Contains(text, "That's not the right answer"):
+		v.Outcome = Wrong
+	case strings.Contains(text, "You gave an answer too recently"):
+		v.Outcome = TooSoon
+	case strings.Contains(text, "You don't seem to be solving the right level"):
+		v.Outcome = AlreadySolved
+	}
+
+	if m := leftPattern.FindStringSubmatch(text); m != nil {
+		if d, err := time.ParseDuration(strings.ReplaceAll(m[1], " ", "")); err == nil {
+			v.Wait = d
+		}
+	} else if m := waitPattern.FindStringSubmatch(text); m != nil {
+		minutes := 1
+		if m[1] != "one" {
+			minutes, _ = strconv.Atoi(m[1])
+		}
+		v.Wait = time.Duration(minutes) * time.Minute
+	}
+
+	return v
+}
@@ -0,0 +1,112 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const (
+	rightAnswer = `<main>
+<article><p>That's the right answer!  You are <span class="day-success">one gold star</span> closer to finding the sleigh keys. <a href="/2021/day/19#part2">[Continue to Part Two]</a></p></article>
+</main>`
+
+	tooHigh = `<main>
+<article><p>That's not the right answer; your answer is too high.  If you're stuck, make sure you're using the full input data; there are also some general tips on the <a href="/2021/about">about page</a>, or you can ask for hints on the <a href="https://www.reddit.com/r/adventofcode/" target="_blank">subreddit</a>.  Please wait one minute before trying again. <a href="/2021/day/19">[Return to Day 19]</a></p></article>
+</main>`
+
+	tooLow = `<main>
+<article><p>That's not the right answer; your answer is too low.  Please wait 5 minutes before trying again. <a href="/2021/day/19">[Return to Day 19]</a></p></article>
+</main>`
+
+	wrong = `<main>
+<article><p>That's not the right answer.  If you're stuck, make sure you're using the full input data.  Please wait one minute before trying again. <a href="/2021/day/19">[Return to Day 19]</a></p></article>
+</main>`
+
+	tooSoon = `<main>
+<article><p>You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 4m 52s left to wait. <a href="/2021/day/19">[Return to Day 19]</a></p></article>
+</main>`
+
+	wrongLevel = `<main>
+<article><p>You don't seem to be solving the right level.  Did you already complete it? <a href="/2021/day/19">[Return to Day 19]</a></p></article>
+</main>`
+)
+
+func TestParseVerdict(t *testing.T) {
+	tests := []struct {
+		page    string
+		outcome Outcome
+		wait    time.Duration
+	}{
+		{rightAnswer, Correct, 0},
+		{tooHigh, TooHigh, time.Minute},
+		{tooLow, TooLow, 5 * time.Minute},
+		{wrong, Wrong, time.Minute},
+		{tooSoon, TooSoon, 4*time.Minute + 52*time.Second},
+		{wrongLevel, AlreadySolved, 0},
+		{"<html>Something else</html>", Unrecognized, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.outcome.String(), func(t *testing.T) {
+			v := parseVerdict(tt.page)
+			if v.Outcome != tt.outcome {
+				t.Errorf("got outcome %s, want %s", v.Outcome, tt.outcome)
+			}
+			if v.Wait != tt.wait {
+				t.Errorf("got wait %s, want %s", v.Wait, tt.wait)
+			}
+			if v.Message == "" {
+				t.Error("got no message")
+			}
+		})
+	}
+}
+
+func TestSubmit(t *testing.T) {
+	var level, answer string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/2021/day/19/answer" {
+			http.NotFound(w, r)
+			return
+		}
+		if cookie, err := r.Cookie("session"); err != nil || cookie.Value != "secret" {
+			http.Error(w, "not logged in", http.StatusBadRequest)
+			return
+		}
+		level, answer = r.PostFormValue("level"), r.PostFormValue("answer")
+
+		if answer == "79" {
+			w.Write([]byte(rightAnswer))
+		} else {
+			w.Write([]byte(tooHigh))
+		}
+	}))
+	defer server.Close()
+
+	c := New("secret")
+	c.BaseURL = server.URL
+	c.HTTP = server.Client()
+	c.Interval = 0
+
+	tests := []struct {
+		answer  string
+		outcome Outcome
+	}{
+		{"79", Correct},
+		{"80", TooHigh},
+	}
+	for _, tt := range tests {
+		v, err := c.Submit(context.Background(), 2021, 19, 2, tt.answer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v.Outcome != tt.outcome {
+			t.Errorf("submitting %s: got outcome %s, want %s", tt.answer, v.Outcome, tt.outcome)
+		}
+		if level != "2" || answer != tt.answer {
+			t.Errorf("server got level %q answer %q, want level \"2\" answer %q", level, answer, tt.answer)
+		}
+	}
+}
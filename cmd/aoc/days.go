@@ -0,0 +1,23 @@
+package main
+
+// Each day registers its solver with the aoc package when imported.
+import (
+	_ "github.com/bclarkx2/aoc/2021/01-sonar-sweep"
+	_ "github.com/bclarkx2/aoc/2021/02-dive"
+	_ "github.com/bclarkx2/aoc/2021/03-binary-diagnostic"
+	_ "github.com/bclarkx2/aoc/2021/04-giant-squid"
+	_ "github.com/bclarkx2/aoc/2021/05-hydrothermal-venture"
+	_ "github.com/bclarkx2/aoc/2021/06-lanternfish"
+	_ "github.com/bclarkx2/aoc/2021/07-the-treachery-of-whales"
+	_ "github.com/bclarkx2/aoc/2021/08-seven-segment-search"
+	_ "github.com/bclarkx2/aoc/2021/09-smoke-basin"
+	_ "github.com/bclarkx2/aoc/2021/10-syntax-scoring"
+	_ "github.com/bclarkx2/aoc/2021/11-dumbo-octopus"
+	_ "github.com/bclarkx2/aoc/2021/12-passage-pathing"
+	_ "github.com/bclarkx2/aoc/2021/13-transparent-origami"
+	_ "github.com/bclarkx2/aoc/2021/14-extended-polymerization"
+	_ "github.com/bclarkx2/aoc/2021/15-chiton"
+	_ "github.com/bclarkx2/aoc/2021/16-packet-decoder"
+	_ "github.com/bclarkx2/aoc/2021/17-trick-shot"
+	_ "github.com/bclarkx2/aoc/2021/18-snailfish"
+)
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/bclarkx2/aoc/client"
+	"github.com/peterbourgon/ff/v3"
+	"github.com/peterbourgon/ff/v3/ffcli"
+)
+
+// clientFlags registers the flags needed to talk to the website, and
+// returns a function that builds a client from them once they are parsed.
+func clientFlags(fs *flag.FlagSet) func() *client.Client {
+	fs.String("config", defaultConfig(), "Config file of flag values, one \"name value\" per line")
+	session := fs.String("session", "", "Session cookie from a logged-in browser")
+	url := fs.String("url", client.DefaultURL, "Address of the Advent of Code website")
+
+	return func() *client.Client {
+		c := client.New(*session)
+		c.BaseURL = *url
+		return c
+	}
+}
+
+// clientOptions lets the client flags be set from AOC_ environment
+// variables or the config file.
+func clientOptions() []ff.Option {
+	return []ff.Option{
+		ff.WithEnvVarPrefix("AOC"),
+		ff.WithConfigFileFlag("config"),
+		ff.WithConfigFileParser(ff.PlainParser),
+		ff.WithAllowMissingConfigFile(true),
+	}
+}
+
+func defaultConfig() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "aoc", "config")
+}
+
+func fetchCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("aoc fetch", flag.ExitOnError)
+	newClient := clientFlags(fs)
+
+	return &ffcli.Command{
+		Name:       "fetch",
+		ShortUsage: "aoc fetch [flags] <year> <day|all> [flags]",
+		ShortHelp:  "Download puzzle inputs into each day's puzzle.txt",
+		LongHelp: "Inputs that have already been downloaded are left alone. The session\n" +
+			"cookie can also be set with AOC_SESSION or in the config file.",
+		FlagSet: fs,
+		Options: clientOptions(),
+		Exec: func(ctx context.Context, args []string) error {
+			days, rest, err := selectDays(args)
+			if err != nil {
+				return err
+			}
+			if err := fs.Parse(rest); err != nil {
+				return err
+			}
+			if fs.NArg() > 0 {
+				return flag.ErrHelp
+			}
+
+			c := newClient()
+			for _, d := range days {
+				path := filepath.Join(d.dir, "puzzle.txt")
+				fetched, err := c.FetchInput(ctx, d.year, d.day, path)
+				if err != nil {
+					return fmt.Errorf("%s: %w", d, err)
+				}
+				if fetched {
+					fmt.Printf("%s: downloaded %s\n", d, path)
+				} else {
+					fmt.Printf("%s: already have %s\n", d, path)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// day is a puzzle's directory, which may not have a registered solver yet.
+type day struct {
+	year, day int
+	dir       string
+}
+
+func (d day) String() string {
+	return fmt.Sprintf("%d Day %d", d.year, d.day)
+}
+
+// selectDays is like selectPuzzles, but also accepts a single day whose
+// directory exists without a registered solver.
+func selectDays(args []string) ([]day, []string, error) {
+	puzzles, rest, err := selectPuzzles(args)
+	if err == nil {
+		days := make([]day, len(puzzles))
+		for i, p := range puzzles {
+			days[i] = day{p.Year, p.Day, p.Dir}
+		}
+		return days, rest, nil
+	}
+	if len(args) < 2 || args[1] == "all" {
+		return nil, nil, err
+	}
+
+	year, yearErr := strconv.Atoi(args[0])
+	d, dayErr := strconv.Atoi(args[1])
+	if yearErr != nil || dayErr != nil {
+		return nil, nil, err
+	}
+
+	dirs, _ := filepath.Glob(fmt.Sprintf("%d/%02d-*", year, d))
+	if len(dirs) != 1 {
+		return nil, nil, fmt.Errorf("no directory for %d day %d; create it with aoc new first", year, d)
+	}
+	return []day{{year, d, dirs[0]}}, args[2:], nil
+}
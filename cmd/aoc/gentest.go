@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"go/build"
+	"go/format"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/template"
+
+	"github.com/bclarkx2/aoc"
+	"github.com/bclarkx2/aoc/aoctest"
+	"github.com/peterbourgon/ff/v3/ffcli"
+)
+
+// puzzleInput is the input that answers are submitted for, so it is left
+// out of the generated tests.
+const puzzleInput = "puzzle.txt"
+
+const testFile = "answers_test.go"
+
+var testTemplate = template.Must(template.New(testFile).Parse(`// Code generated by "aoc gentest {{.Year}} {{.Day}}"; DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"testing"
+
+	"github.com/bclarkx2/aoc/aoctest"
+)
+
+func TestAnswers(t *testing.T) {
+	aoctest.RunPuzzle(t, {{.Year}}, {{.Day}}, []aoctest.Case{
+		{{- range .Cases}}
+		{Input: {{printf "%q" .Input}}, Part: {{.Part}}, Want: {{printf "%q" .Want}}},
+		{{- end}}
+	})
+}
+`))
+
+func gentestCommand() *ffcli.Command {
+	return &ffcli.Command{
+		Name:       "gentest",
+		ShortUsage: "aoc gentest <year> <day|all>",
+		ShortHelp:  "Generate tests from the answers recorded for example inputs",
+		LongHelp: "Writes " + testFile + " in each selected day's directory, checking\n" +
+			"every input in its answers file except " + puzzleInput + ".",
+		Exec: func(_ context.Context, args []string) error {
+			puzzles, rest, err := selectPuzzles(args)
+			if err != nil {
+				return err
+			}
+			if len(rest) > 0 {
+				return flag.ErrHelp
+			}
+
+			for _, p := range puzzles {
+				if err := gentest(p); err != nil {
+					return fmt.Errorf("%s: %w", p, err)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func gentest(p aoc.Puzzle) error {
+	pkg, err := build.ImportDir(p.Dir, 0)
+	if err != nil {
+		return err
+	}
+
+	answers, err := aoc.LoadAnswers(p.Dir)
+	if err != nil {
+		return err
+	}
+
+	var cases []aoctest.Case
+	for input, parts := range answers {
+		if input == puzzleInput {
+			continue
+		}
+		for part, want := range parts {
+			cases = append(cases, aoctest.Case{
+				Input: input,
+				Part:  part,
+				Want:  want,
+			})
+		}
+	}
+	if len(cases) == 0 {
+		fmt.Printf("%s: no example answers recorded, skipping\n", p)
+		return nil
+	}
+	sort.Slice(cases, func(i, j int) bool {
+		if cases[i].Input != cases[j].Input {
+			return cases[i].Input < cases[j].Input
+		}
+		return cases[i].Part < cases[j].Part
+	})
+
+	var buf bytes.Buffer
+	err = testTemplate.Execute(&buf, struct {
+		Year    int
+		Day     int
+		Package string
+		Cases   []aoctest.Case
+	}{p.Year, p.Day, pkg.Name, cases})
+	if err != nil {
+		return err
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(p.Dir, testFile)
+	if err := os.WriteFile(path, src, 0644); err != nil {
+		return err
+	}
+	fmt.Printf("%s: wrote %d cases to %s\n", p, len(cases), path)
+	return nil
+}
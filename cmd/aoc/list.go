@@ -0,0 +1,27 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/bclarkx2/aoc"
+	"github.com/peterbourgon/ff/v3/ffcli"
+)
+
+func listCommand() *ffcli.Command {
+	return &ffcli.Command{
+		Name:       "list",
+		ShortUsage: "aoc list",
+		ShortHelp:  "List every registered puzzle",
+		Exec: func(_ context.Context, args []string) error {
+			if len(args) > 0 {
+				return flag.ErrHelp
+			}
+			for _, p := range aoc.Puzzles() {
+				fmt.Println(p)
+			}
+			return nil
+		},
+	}
+}
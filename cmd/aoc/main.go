@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+)
+
+func main() {
+	root := &ffcli.Command{
+		ShortUsage: "aoc <subcommand> [flags] [<args>...]",
+		Subcommands: []*ffcli.Command{
+			runCommand(),
+			listCommand(),
+			newCommand(),
+			gentestCommand(),
+			statsCommand(),
+			watchCommand(),
+			fetchCommand(),
+			submitCommand(),
+		},
+		Exec: func(context.Context, []string) error {
+			return flag.ErrHelp
+		},
+	}
+
+	if err := root.ParseAndRun(context.Background(), os.Args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(2)
+		}
+		log.Fatal(err)
+	}
+}
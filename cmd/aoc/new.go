@@ -0,0 +1,195 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/bclarkx2/aoc"
+	"github.com/peterbourgon/ff/v3/ffcli"
+)
+
+const (
+	modulePath = "github.com/bclarkx2/aoc"
+	daysFile   = "cmd/aoc/days.go"
+)
+
+func newCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("aoc new", flag.ExitOnError)
+	newClient := clientFlags(fs)
+	title := fs.String("title", "", "Puzzle title, looked up on the website if not given")
+	templateDir := fs.String("template", "template", "Directory holding main.go.tmpl")
+
+	return &ffcli.Command{
+		Name:       "new",
+		ShortUsage: "aoc new [flags] <year> <day> [flags]",
+		ShortHelp:  "Create the package for a new day's puzzle",
+		LongHelp: "Creates <year>/<NN>-<slug> from the template, with empty inputs and\n" +
+			"answers, and imports it into the aoc command. Run from the root of the\n" +
+			"repository.",
+		FlagSet: fs,
+		Options: clientOptions(),
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) < 2 {
+				return flag.ErrHelp
+			}
+			year, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid year %q", args[0])
+			}
+			day, err := strconv.Atoi(args[1])
+			if err != nil || day < 1 || day > 25 {
+				return fmt.Errorf("invalid day %q", args[1])
+			}
+			if err := fs.Parse(args[2:]); err != nil {
+				return err
+			}
+			if fs.NArg() > 0 {
+				return flag.ErrHelp
+			}
+
+			if p, ok := aoc.Lookup(year, day); ok {
+				return fmt.Errorf("%s already exists", p)
+			}
+			if dirs, _ := filepath.Glob(fmt.Sprintf("%d/%02d-*", year, day)); len(dirs) > 0 {
+				return fmt.Errorf("%s already exists", dirs[0])
+			}
+
+			if *title == "" {
+				*title, err = newClient().Title(ctx, year, day)
+				if err != nil {
+					return fmt.Errorf("looking up title: %w (set one with -title)", err)
+				}
+			}
+
+			return scaffold(*templateDir, year, day, *title)
+		},
+	}
+}
+
+// scaffold creates the package for a day and imports it into the aoc
+// command.
+func scaffold(templateDir string, year, day int, title string) error {
+	slug := slugify(title)
+	if slug == "" {
+		return fmt.Errorf("cannot make a directory name from title %q", title)
+	}
+	dir := filepath.Join(strconv.Itoa(year), fmt.Sprintf("%02d-%s", day, slug))
+	pkg := strings.ReplaceAll(slug, "-", "")
+	if unicode.IsDigit(rune(pkg[0])) {
+		pkg = "day" + pkg
+	}
+
+	mainTemplate, err := template.ParseFiles(filepath.Join(templateDir, "main.go.tmpl"))
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dir), 0755); err != nil {
+		return err
+	}
+	if err := os.Mkdir(dir, 0755); err != nil {
+		return err
+	}
+
+	data := struct {
+		Year    int
+		Day     int
+		Title   string
+		Package string
+		Cases   []struct{}
+	}{year, day, title, pkg, nil}
+
+	files := []struct {
+		name     string
+		template *template.Template
+	}{
+		{"main.go", mainTemplate},
+		{testFile, testTemplate},
+		{"example.txt", nil},
+		{puzzleInput, nil},
+		{"answers", nil},
+	}
+	for _, f := range files {
+		var src []byte
+		if f.template != nil {
+			var buf bytes.Buffer
+			if err := f.template.Execute(&buf, data); err != nil {
+				return err
+			}
+			if src, err = format.Source(buf.Bytes()); err != nil {
+				return fmt.Errorf("%s: %w", f.name, err)
+			}
+		}
+		if err := os.WriteFile(filepath.Join(dir, f.name), src, 0644); err != nil {
+			return err
+		}
+	}
+
+	if err := addImport(daysFile, modulePath+"/"+filepath.ToSlash(dir)); err != nil {
+		return err
+	}
+
+	fmt.Printf("Created %s\n", dir)
+	fmt.Printf("Run \"aoc fetch %d %d\" to download its input.\n", year, day)
+	return nil
+}
+
+// slugify turns a puzzle title into the lower-case, dash-separated form used
+// in directory names, e.g. "The Treachery of Whales" becomes
+// "the-treachery-of-whales".
+func slugify(title string) string {
+	words := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, "-")
+}
+
+// addImport adds a blank import of pkg to the import block in path, keeping
+// the imports sorted.
+func addImport(path, pkg string) error {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	lines := strings.Split(string(src), "\n")
+	start, end := -1, -1
+	for i, line := range lines {
+		switch {
+		case line == "import (":
+			start = i + 1
+		case line == ")" && start >= 0:
+			end = i
+		}
+		if end >= 0 {
+			break
+		}
+	}
+	if start < 0 || end < 0 {
+		return fmt.Errorf("%s: no import block found", path)
+	}
+
+	imports := append([]string{fmt.Sprintf("\t_ %q", pkg)}, lines[start:end]...)
+	sort.Strings(imports)
+
+	var buf bytes.Buffer
+	buf.WriteString(strings.Join(lines[:start], "\n") + "\n")
+	buf.WriteString(strings.Join(imports, "\n") + "\n")
+	buf.WriteString(strings.Join(lines[end:], "\n"))
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, formatted, 0644)
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+
+	"github.com/bclarkx2/aoc"
+	"github.com/peterbourgon/ff/v3"
+	"github.com/peterbourgon/ff/v3/ffcli"
+)
+
+func runCommand() *ffcli.Command {
+	var opts aoc.Options
+	fs := flag.NewFlagSet("aoc run", flag.ExitOnError)
+	opts.RegisterFlags(fs)
+
+	return &ffcli.Command{
+		Name:       "run",
+		ShortUsage: "aoc run [flags] <year> <day|all> [flags]",
+		ShortHelp:  "Run the solver for one day, or every day in a year",
+		LongHelp: "The -input flag is resolved relative to each day's directory,\n" +
+			"so the default runs every selected day against its puzzle.txt.\n\n" +
+			"Some days have flags of their own, like -steps for 2021 day 14,\n" +
+			"which must be given after the day. A flag that several days share\n" +
+			"applies to each of them. Days whose flags are set are not checked\n" +
+			"against their answers or recorded in the history.",
+		FlagSet: fs,
+		Options: []ff.Option{ff.WithEnvVarPrefix("AOC")},
+		Exec: func(_ context.Context, args []string) error {
+			puzzles, rest, err := selectPuzzles(args)
+			if err != nil {
+				return err
+			}
+
+			// The selected days' own flags can only be known now, so they
+			// and any other flags must follow the positional arguments.
+			flags, err := aoc.RegisterSolverFlags(fs, puzzles...)
+			if err != nil {
+				return err
+			}
+			if err := ff.Parse(fs, rest, ff.WithEnvVarPrefix("AOC")); err != nil {
+				return err
+			}
+			opts.Unverified = flags.Unverified
+			if fs.NArg() > 0 {
+				return flag.ErrHelp
+			}
+
+			return aoc.RunPuzzles(opts, puzzles...)
+		},
+	}
+}
+
+// selectPuzzles picks out the puzzles named by the leading <year> <day|all>
+// arguments and returns whatever arguments follow them.
+func selectPuzzles(args []string) ([]aoc.Puzzle, []string, error) {
+	if len(args) < 2 {
+		return nil, nil, flag.ErrHelp
+	}
+
+	year, err := strconv.Atoi(args[0])
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid year %q", args[0])
+	}
+
+	if args[1] == "all" {
+		var puzzles []aoc.Puzzle
+		for _, p := range aoc.Puzzles() {
+			if p.Year == year {
+				puzzles = append(puzzles, p)
+			}
+		}
+		if len(puzzles) == 0 {
+			return nil, nil, fmt.Errorf("no puzzles registered for %d", year)
+		}
+		return puzzles, args[2:], nil
+	}
+
+	day, err := strconv.Atoi(args[1])
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid day %q", args[1])
+	}
+
+	p, ok := aoc.Lookup(year, day)
+	if !ok {
+		return nil, nil, fmt.Errorf("no puzzle registered for %d day %d", year, day)
+	}
+	return []aoc.Puzzle{p}, args[2:], nil
+}
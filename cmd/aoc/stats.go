@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/bclarkx2/aoc"
+	"github.com/peterbourgon/ff/v3"
+	"github.com/peterbourgon/ff/v3/ffcli"
+)
+
+func statsCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("aoc stats", flag.ExitOnError)
+	history := fs.String("history", aoc.DefaultHistory, "File run timings were recorded in")
+	threshold := fs.Float64("threshold", 1.5, "Flag parts whose latest run took this many times their best")
+	min := fs.Duration("min", time.Millisecond, "Ignore slowdowns in parts whose latest run took less than this")
+
+	return &ffcli.Command{
+		Name:       "stats",
+		ShortUsage: "aoc stats [flags]",
+		ShortHelp:  "Summarize recorded run timings and flag regressions",
+		LongHelp: "Compares the latest recorded run of each part and input against the\n" +
+			"best run recorded for the same input contents and timing mode, wherever\n" +
+			"the input file was.",
+		FlagSet: fs,
+		Options: []ff.Option{ff.WithEnvVarPrefix("AOC")},
+		Exec: func(_ context.Context, args []string) error {
+			if len(args) > 0 {
+				return flag.ErrHelp
+			}
+
+			records, err := aoc.LoadHistory(*history)
+			if err != nil {
+				return err
+			}
+			if len(records) == 0 {
+				return fmt.Errorf("no runs recorded in %s", *history)
+			}
+
+			summaries := summarize(records)
+			regressions := 0
+			tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(tw, "YEAR\tDAY\tPART\tINPUT\tMODE\tRUNS\tBEST\tLATEST\tCOMMIT\t")
+			for _, s := range summaries {
+				note := ""
+				if s.slower(*threshold, *min) {
+					note = fmt.Sprintf("SLOWER (%.1fx)", s.ratio())
+					regressions++
+				}
+				fmt.Fprintf(
+					tw,
+					"%d\t%d\t%s\t%s\t%s\t%d\t%s\t%s\t%s\t%s\n",
+					s.latest.Year,
+					s.latest.Day,
+					partName(s.latest.Part),
+					s.latest.Input,
+					modeName(s.latest.Mode),
+					s.runs,
+					round(s.best.Duration),
+					round(s.latest.Duration),
+					s.latest.Commit,
+					note,
+				)
+			}
+			tw.Flush()
+
+			if regressions > 0 {
+				return fmt.Errorf("%d of %d parts got slower than their best run", regressions, len(summaries))
+			}
+			return nil
+		},
+	}
+}
+
+// summary describes the recorded runs of one part against one input.
+type summary struct {
+	runs   int
+	best   aoc.Record
+	latest aoc.Record
+}
+
+func (s summary) ratio() float64 {
+	if s.best.Duration == 0 {
+		return 1
+	}
+	return float64(s.latest.Duration) / float64(s.best.Duration)
+}
+
+// slower reports whether the latest run took more than threshold times as
+// long as the best one. Runs quicker than min are too noisy to count.
+func (s summary) slower(threshold float64, min time.Duration) bool {
+	return s.latest.Duration >= min && s.ratio() > threshold
+}
+
+// summaryKey identifies the runs whose timings are comparable. Inputs are
+// told apart by their contents, since the same file has a different path
+// from each directory the command is run in.
+type summaryKey struct {
+	year, day, part int
+	hash, mode      string
+}
+
+// summarize groups records by puzzle, part, input and mode, ordered by
+// year, day, input and part.
+func summarize(records []aoc.Record) []summary {
+	byKey := map[summaryKey]*summary{}
+	for _, r := range records {
+		key := summaryKey{r.Year, r.Day, r.Part, r.InputHash, r.Mode}
+		s, ok := byKey[key]
+		if !ok {
+			s = &summary{best: r}
+			byKey[key] = s
+		}
+		s.runs++
+		s.latest = r
+		if r.Duration < s.best.Duration {
+			s.best = r
+		}
+	}
+
+	var summaries []summary
+	for _, s := range byKey {
+		summaries = append(summaries, *s)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		a, b := summaries[i].latest, summaries[j].latest
+		switch {
+		case a.Year != b.Year:
+			return a.Year < b.Year
+		case a.Day != b.Day:
+			return a.Day < b.Day
+		case a.Input != b.Input:
+			return a.Input < b.Input
+		case a.Part != b.Part:
+			return a.Part < b.Part
+		case a.Mode != b.Mode:
+			return a.Mode < b.Mode
+		default:
+			return a.Time.Before(b.Time)
+		}
+	})
+	return summaries
+}
+
+func partName(part int) string {
+	if part == 0 {
+		return "parse"
+	}
+	return fmt.Sprint(part)
+}
+
+func modeName(mode string) string {
+	if mode == "" {
+		return "single"
+	}
+	return mode
+}
+
+// round trims a duration to a readable precision.
+func round(d time.Duration) time.Duration {
+	switch {
+	case d > time.Second:
+		return d.Round(time.Millisecond)
+	case d > time.Millisecond:
+		return d.Round(time.Microsecond)
+	default:
+		return d
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bclarkx2/aoc"
+)
+
+func TestSummarize(t *testing.T) {
+	start := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	var records []aoc.Record
+	record := func(input, hash, mode string, d time.Duration) {
+		records = append(records, aoc.Record{
+			Time:      start.Add(time.Duration(len(records)) * time.Minute),
+			Year:      2021,
+			Day:       1,
+			Part:      1,
+			Input:     input,
+			InputHash: hash,
+			Duration:  d,
+			Mode:      mode,
+		})
+	}
+	record("puzzle.txt", "a", "", 10*time.Millisecond)
+	record("2021/01/puzzle.txt", "a", "", 4*time.Millisecond)
+	record("puzzle.txt", "a", "bench", 3*time.Millisecond)
+	record("example.txt", "b", "", 100*time.Microsecond)
+	record("puzzle.txt", "a", "", 8*time.Millisecond)
+	record("puzzle.txt", "a", "bench", 4*time.Millisecond)
+	record("example.txt", "b", "", 500*time.Microsecond)
+
+	tests := []struct {
+		input        string
+		mode         string
+		runs         int
+		best, latest time.Duration
+		slower       bool
+	}{
+		// Five times slower, but too quick to tell.
+		{"example.txt", "", 2, 100 * time.Microsecond, 500 * time.Microsecond, false},
+		// Compared with the best run of the same contents at another path.
+		{"puzzle.txt", "", 3, 4 * time.Millisecond, 8 * time.Millisecond, true},
+		// Compared only with other benchmarks, and within the threshold.
+		{"puzzle.txt", "bench", 2, 3 * time.Millisecond, 4 * time.Millisecond, false},
+	}
+
+	summaries := summarize(records)
+	if len(summaries) != len(tests) {
+		t.Fatalf("got %d summaries, want %d", len(summaries), len(tests))
+	}
+	for i, tt := range tests {
+		s := summaries[i]
+		if s.latest.Input != tt.input || s.latest.Mode != tt.mode {
+			t.Errorf("summary %d: got input %s mode %q, want %s mode %q", i, s.latest.Input, s.latest.Mode, tt.input, tt.mode)
+			continue
+		}
+		if s.runs != tt.runs {
+			t.Errorf("%s %s: got %d runs, want %d", tt.input, modeName(tt.mode), s.runs, tt.runs)
+		}
+		if s.best.Duration != tt.best || s.latest.Duration != tt.latest {
+			t.Errorf("%s %s: got best %s latest %s, want %s and %s", tt.input, modeName(tt.mode), s.best.Duration, s.latest.Duration, tt.best, tt.latest)
+		}
+		if got := s.slower(1.5, time.Millisecond); got != tt.slower {
+			t.Errorf("%s %s: got slower %t, want %t", tt.input, modeName(tt.mode), got, tt.slower)
+		}
+	}
+
+	if !summaries[0].slower(1.5, 0) {
+		t.Error("got a fivefold slowdown not flagged without a minimum")
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+
+	"github.com/bclarkx2/aoc"
+	"github.com/bclarkx2/aoc/client"
+	"github.com/peterbourgon/ff/v3/ffcli"
+)
+
+func submitCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("aoc submit", flag.ExitOnError)
+	newClient := clientFlags(fs)
+	var opts aoc.Options
+	fs.DurationVar(&opts.Timeout, "timeout", 0, "Give up on the solver after this long, e.g. 10s")
+
+	return &ffcli.Command{
+		Name:       "submit",
+		ShortUsage: "aoc submit [flags] <year> <day> <part> [flags]",
+		ShortHelp:  "Solve one part against puzzle.txt and submit the answer",
+		LongHelp: "The website's verdict is recorded in the day's answers file. Answers\n" +
+			"already known to be wrong, or already recorded as correct, are not\n" +
+			"submitted.",
+		FlagSet: fs,
+		Options: clientOptions(),
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) < 3 {
+				return flag.ErrHelp
+			}
+			puzzles, _, err := selectPuzzles(args[:2])
+			if err != nil {
+				return err
+			}
+			p := puzzles[0]
+			part, err := strconv.Atoi(args[2])
+			if err != nil || part < 1 || part > 2 {
+				return fmt.Errorf("invalid part %q", args[2])
+			}
+			if err := fs.Parse(args[3:]); err != nil {
+				return err
+			}
+			if fs.NArg() > 0 {
+				return flag.ErrHelp
+			}
+
+			opts.Input = puzzleInput
+			opts.Part = part
+			return submit(ctx, newClient(), p, part, opts)
+		},
+	}
+}
+
+// submit solves one part of a puzzle and submits the answer, unless it is
+// already known to be right or wrong.
+func submit(ctx context.Context, c *client.Client, p aoc.Puzzle, part int, opts aoc.Options) error {
+	results, _ := aoc.Solve(opts, p)
+	var result *aoc.Result
+	for i := range results {
+		if results[i].Part == part {
+			result = &results[i]
+		}
+	}
+	switch {
+	case result == nil:
+		return fmt.Errorf("%s: could not run part %d", p, part)
+	case result.Error != "":
+		return fmt.Errorf("%s: part %d: %s", p, part, result.Error)
+	case result.Status == "PASS":
+		fmt.Printf("%s: part %d answer %s is already recorded as correct\n", p, part, result.Answer)
+		return nil
+	case result.Status == "FAIL":
+		return fmt.Errorf("%s: part %d answer %s differs from the recorded correct answer", p, part, result.Answer)
+	}
+
+	rejections, err := aoc.LoadRejections(p.Dir)
+	if err != nil {
+		return err
+	}
+	if err := rejections.Check(puzzleInput, part, result.Answer); err != nil {
+		return fmt.Errorf("%s: part %d: not submitting: %w", p, part, err)
+	}
+
+	fmt.Printf("%s: submitting %s for part %d\n", p, result.Answer, part)
+	verdict, err := c.Submit(ctx, p.Year, p.Day, part, result.Answer)
+	if err != nil {
+		return err
+	}
+
+	switch verdict.Outcome {
+	case client.Correct:
+		fmt.Println("That's the right answer!")
+		return aoc.RecordAnswer(p.Dir, puzzleInput, part, result.Answer)
+	case client.Wrong, client.TooHigh, client.TooLow:
+		r := aoc.Rejection{Answer: result.Answer}
+		switch verdict.Outcome {
+		case client.TooHigh:
+			r.Bound = '<'
+		case client.TooLow:
+			r.Bound = '>'
+		}
+		if err := aoc.RecordRejection(p.Dir, puzzleInput, part, r); err != nil {
+			return err
+		}
+	}
+
+	msg := fmt.Sprintf("%s: %s", verdict.Outcome, verdict.Message)
+	if verdict.Wait > 0 {
+		msg += fmt.Sprintf(" (wait %s)", verdict.Wait)
+	}
+	return errors.New(msg)
+}
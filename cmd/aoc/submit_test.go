@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/bclarkx2/aoc"
+	"github.com/bclarkx2/aoc/client"
+)
+
+// guess is a solver whose answer to part 1 can be changed between runs.
+type guess struct {
+	answer int
+}
+
+func (g *guess) Solve1(input []string) (aoc.Answer, error) {
+	return g.answer, nil
+}
+
+func (g *guess) Solve2(input []string) (aoc.Answer, error) {
+	return nil, nil
+}
+
+func TestSubmit(t *testing.T) {
+	var posted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		answer := r.PostFormValue("answer")
+		posted = append(posted, answer)
+
+		n, _ := strconv.Atoi(answer)
+		switch {
+		case n == 42:
+			w.Write([]byte("<article><p>That's the right answer!</p></article>"))
+		case n > 42:
+			w.Write([]byte("<article><p>That's not the right answer; your answer is too high.  Please wait one minute before trying again.</p></article>"))
+		default:
+			w.Write([]byte("<article><p>That's not the right answer; your answer is too low.  Please wait one minute before trying again.</p></article>"))
+		}
+	}))
+	defer server.Close()
+
+	c := client.New("secret")
+	c.BaseURL = server.URL
+	c.HTTP = server.Client()
+	c.Interval = 0
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, puzzleInput), []byte("input\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	solver := &guess{}
+	p := aoc.Puzzle{Year: 2021, Day: 1, Title: "Test", Solver: solver, Dir: dir}
+	opts := aoc.Options{Input: puzzleInput, Part: 1}
+
+	answers := func() string {
+		b, err := os.ReadFile(filepath.Join(dir, "answers"))
+		if err != nil && !os.IsNotExist(err) {
+			t.Fatal(err)
+		}
+		return string(b)
+	}
+
+	solver.answer = 50
+	if err := submit(context.Background(), c, p, 1, opts); err == nil || !strings.HasPrefix(err.Error(), "too high") {
+		t.Fatalf("submitting 50: got error %v, want too high", err)
+	}
+	if got, want := answers(), "puzzle.txt 1 < 50\n"; got != want {
+		t.Fatalf("after submitting 50, answers file is %q, want %q", got, want)
+	}
+
+	for _, answer := range []int{50, 60} {
+		solver.answer = answer
+		if err := submit(context.Background(), c, p, 1, opts); err == nil || !strings.Contains(err.Error(), "not submitting") {
+			t.Errorf("submitting %d: got error %v, want it not submitted", answer, err)
+		}
+	}
+	if len(posted) != 1 {
+		t.Errorf("posted %q, want only the first answer", posted)
+	}
+
+	solver.answer = 42
+	if err := submit(context.Background(), c, p, 1, opts); err != nil {
+		t.Fatalf("submitting 42: %s", err)
+	}
+	if got, want := answers(), "puzzle.txt 1 < 50\npuzzle.txt 1 42\n"; got != want {
+		t.Errorf("after submitting 42, answers file is %q, want %q", got, want)
+	}
+
+	results, err := aoc.Solve(opts, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var status string
+	for _, r := range results {
+		if r.Part == 1 {
+			status = r.Status
+		}
+	}
+	if status != "PASS" {
+		t.Errorf("got status %q for the correct answer, want PASS", status)
+	}
+}
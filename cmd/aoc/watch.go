@@ -0,0 +1,217 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/bclarkx2/aoc"
+	"github.com/peterbourgon/ff/v3"
+	"github.com/peterbourgon/ff/v3/ffcli"
+)
+
+func watchCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("aoc watch", flag.ExitOnError)
+	input := fs.String("input", "example.txt", "Input file, glob or directory")
+	timeout := fs.Duration("timeout", 0, "Give up on a part after this long, e.g. 10s")
+	interval := fs.Duration("interval", 500*time.Millisecond, "How often to check for changes")
+
+	return &ffcli.Command{
+		Name:       "watch",
+		ShortUsage: "aoc watch [flags] <year> <day> [flags]",
+		ShortHelp:  "Re-run a day whenever its code or inputs change",
+		LongHelp: "Each run rebuilds the aoc command, so changes to the day's code take\n" +
+			"effect. Answers are compared against the previous run and against the\n" +
+			"answers file.",
+		FlagSet: fs,
+		Options: []ff.Option{ff.WithEnvVarPrefix("AOC")},
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) < 2 || args[1] == "all" {
+				return flag.ErrHelp
+			}
+			puzzles, rest, err := selectPuzzles(args)
+			if err != nil {
+				return err
+			}
+			if err := fs.Parse(rest); err != nil {
+				return err
+			}
+			if fs.NArg() > 0 {
+				return flag.ErrHelp
+			}
+
+			w := watcher{
+				puzzle:  puzzles[0],
+				input:   *input,
+				timeout: *timeout,
+			}
+			return w.watch(ctx, *interval)
+		},
+	}
+}
+
+// watcher re-runs a puzzle when the files in its directory change.
+type watcher struct {
+	puzzle  aoc.Puzzle
+	input   string
+	timeout time.Duration
+
+	// previous holds the answers from the last run, keyed by input and part.
+	previous map[resultKey]string
+}
+
+type resultKey struct {
+	input string
+	part  int
+}
+
+func (w *watcher) watch(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var last map[string]time.Time
+	for {
+		current, err := modTimes(w.puzzle.Dir)
+		if err != nil {
+			return err
+		}
+		if changed(last, current) {
+			last = current
+			w.run(ctx)
+			fmt.Printf("\nWatching %s for changes...\n", w.puzzle.Dir)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// modTimes returns the modification time of every file in dir that can
+// change a day's answers.
+func modTimes(dir string) (map[string]time.Time, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	times := map[string]time.Time{}
+	for _, entry := range entries {
+		name := entry.Name()
+		ext := filepath.Ext(name)
+		if entry.IsDir() || (ext != ".go" && ext != ".txt" && name != "answers") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		times[name] = info.ModTime()
+	}
+	return times, nil
+}
+
+func changed(before, after map[string]time.Time) bool {
+	if before == nil || len(before) != len(after) {
+		return true
+	}
+	for name, t := range after {
+		if !before[name].Equal(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// run rebuilds and runs the aoc command for the puzzle, then prints how its
+// answers compare.
+func (w *watcher) run(ctx context.Context) {
+	fmt.Printf("\n== %s == %s\n", w.puzzle, time.Now().Format("15:04:05"))
+
+	cmd := exec.CommandContext(
+		ctx,
+		"go", "run", "./cmd/aoc", "run",
+		"-output", "json",
+		"-history", "",
+		"-input", w.input,
+		"-timeout", w.timeout.String(),
+		fmt.Sprint(w.puzzle.Year), fmt.Sprint(w.puzzle.Day),
+	)
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+	runErr := cmd.Run()
+
+	var results []aoc.Result
+	scanner := bufio.NewScanner(&stdout)
+	for scanner.Scan() {
+		var r aoc.Result
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			fmt.Fprintf(os.Stderr, "reading results: %s\n", err)
+			return
+		}
+		results = append(results, r)
+	}
+	if len(results) == 0 {
+		if runErr != nil {
+			fmt.Fprintf(os.Stderr, "run failed: %s\n", runErr)
+		}
+		return
+	}
+
+	answers, err := aoc.LoadAnswers(w.puzzle.Dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "reading answers: %s\n", err)
+	}
+
+	current := map[resultKey]string{}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "INPUT\tPART\tANSWER\tTIME\tSTATUS\tEXPECTED\tPREVIOUS")
+	for _, r := range results {
+		if r.Part == 0 {
+			continue
+		}
+		key := resultKey{r.Input, r.Part}
+		answer := r.Answer
+		if r.Error != "" {
+			answer = "error: " + r.Error
+		}
+		current[key] = answer
+
+		expected, ok := answers.Expected(r.Input, r.Part)
+		if !ok || r.Status == "PASS" {
+			expected = ""
+		}
+
+		previous := "new"
+		if prev, ok := w.previous[key]; ok && prev == answer {
+			previous = "same"
+		} else if ok {
+			previous = "was " + aoc.SingleLine(prev)
+		}
+
+		fmt.Fprintf(
+			tw,
+			"%s\t%d\t%s\t%s\t%s\t%s\t%s\n",
+			filepath.Base(r.Input),
+			r.Part,
+			aoc.SingleLine(answer),
+			r.Duration.Round(time.Microsecond),
+			r.Status,
+			aoc.SingleLine(expected),
+			previous,
+		)
+	}
+	tw.Flush()
+	w.previous = current
+}
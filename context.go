@@ -0,0 +1,93 @@
+package aoc
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+)
+
+// ContextSolver is an optional interface for solvers that can stop early.
+// When a Solver also implements ContextSolver, Run calls the context
+// variants instead, cancelling the context once a part exceeds its timeout.
+// Parts that do not stop are abandoned and keep running in the background.
+type ContextSolver interface {
+	Solve1Context(ctx context.Context, input []string) (Answer, error)
+	Solve2Context(ctx context.Context, input []string) (Answer, error)
+}
+
+// Cancellable adapts a ContextSolver to the Solver interface. The returned
+// Solver runs without a deadline when used directly, but Run recognizes it
+// as a ContextSolver.
+func Cancellable(s ContextSolver) Solver {
+	return cancellable{s}
+}
+
+type cancellable struct {
+	ContextSolver
+}
+
+func (c cancellable) Solve1(input []string) (Answer, error) {
+	return c.Solve1Context(context.Background(), input)
+}
+
+func (c cancellable) Solve2(input []string) (Answer, error) {
+	return c.Solve2Context(context.Background(), input)
+}
+
+// Flags registers the flags of the wrapped ContextSolver, if it has any.
+func (c cancellable) Flags(fs *flag.FlagSet) {
+	if f, ok := c.ContextSolver.(Flagger); ok {
+		f.Flags(fs)
+	}
+}
+
+// partFunc runs one part of a solver, or parses its input.
+type partFunc func(ctx context.Context) (Answer, error)
+
+// call runs f, giving up on it once timeout has passed. A zero timeout
+// waits for f however long it takes. Panics are returned as errors.
+func call(f partFunc, timeout time.Duration) (Answer, error) {
+	f = protect(f)
+	if timeout <= 0 {
+		return f(context.Background())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	type result struct {
+		answer Answer
+		err    error
+	}
+	done := make(chan result, 1)
+	go func() {
+		answer, err := f(ctx)
+		done <- result{answer, err}
+	}()
+
+	// When the answer and the deadline are both ready, select picks either,
+	// so an answer is only accepted if the deadline hasn't passed.
+	select {
+	case r := <-done:
+		if ctx.Err() != nil || errors.Is(r.err, context.DeadlineExceeded) {
+			return nil, timeoutError{timeout}
+		}
+		return r.answer, r.err
+	case <-ctx.Done():
+		return nil, timeoutError{timeout}
+	}
+}
+
+type timeoutError struct {
+	timeout time.Duration
+}
+
+func (e timeoutError) Error() string {
+	return fmt.Sprintf("timed out after %s", e.timeout)
+}
+
+func (e timeoutError) Unwrap() error {
+	return context.DeadlineExceeded
+}
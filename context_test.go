@@ -0,0 +1,82 @@
+package aoc
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+// slow is a ContextSolver whose first part takes longer than any timeout,
+// stopping when its context is done only if it honours it.
+type slow struct {
+	honours bool
+}
+
+func (s slow) Solve1Context(ctx context.Context, input []string) (Answer, error) {
+	if !s.honours {
+		time.Sleep(time.Second)
+		return 1, nil
+	}
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (s slow) Solve2Context(ctx context.Context, input []string) (Answer, error) {
+	return 2, nil
+}
+
+// slowParsed is a ContextParser whose first part stops only when its
+// context is done.
+type slowParsed struct{}
+
+func (slowParsed) Parse(input []string) (interface{}, error) {
+	return input, nil
+}
+
+func (slowParsed) SolveParsed1Context(ctx context.Context, parsed interface{}) (Answer, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (slowParsed) SolveParsed2Context(ctx context.Context, parsed interface{}) (Answer, error) {
+	return 2, nil
+}
+
+func TestTimeout(t *testing.T) {
+	tests := []struct {
+		name   string
+		solver Solver
+	}{
+		{"returns ctx.Err()", Cancellable(slow{honours: true})},
+		{"ignores the context", Cancellable(slow{honours: false})},
+		{"parses once", CancellableParsed(slowParsed{})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := Puzzle{Year: 2021, Day: 1, Solver: tt.solver}
+			opts := Options{Timeout: 20 * time.Millisecond}
+
+			start := time.Now()
+			results, err := SolveReader(opts, p, strings.NewReader("input\n"))
+			if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+				t.Errorf("took %s, want the slow part abandoned", elapsed)
+			}
+			if err == nil {
+				t.Error("got no error for a part that timed out")
+			}
+
+			parts := map[int]Result{}
+			for _, r := range results {
+				parts[r.Part] = r
+			}
+			if r := parts[1]; r.Status != "FAIL" || r.Error != "timed out after 20ms" {
+				t.Errorf("part 1: got status %q error %q, want FAIL timed out after 20ms", r.Status, r.Error)
+			}
+			if r := parts[2]; r.Error != "" || r.Answer != "2" {
+				t.Errorf("part 2: got answer %q error %q, want 2", r.Answer, r.Error)
+			}
+		})
+	}
+}
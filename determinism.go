@@ -0,0 +1,80 @@
+package aoc
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// determinism calls f the given number of times and fails the execution if
+// its answers differ, which usually means the solver depends on the order of
+// a map iteration. When perturb is set, the garbage collector is run and its
+// target percentage changed before each run, to shake out solvers sensitive
+// to memory layout or timing as well.
+func determinism(part int, f partFunc, runs int, perturb bool, timeout time.Duration) execution {
+	if perturb {
+		defer debug.SetGCPercent(debug.SetGCPercent(100))
+	}
+
+	var first execution
+	counts := map[string]int{}
+	for i := 0; i < runs; i++ {
+		if perturb {
+			runtime.GC()
+			debug.SetGCPercent(10 + rand.Intn(400))
+		}
+
+		e := run(part, f, timeout)
+		if e.err != nil {
+			return e
+		}
+		if i == 0 {
+			first = e
+		}
+		counts[FormatAnswer(e.solution)]++
+	}
+
+	if len(counts) > 1 {
+		first.err = nondeterministicError{runs, counts}
+	}
+	return first
+}
+
+// nondeterministicError reports the different answers a part gave when it
+// was run repeatedly.
+type nondeterministicError struct {
+	runs   int
+	counts map[string]int
+}
+
+func (e nondeterministicError) Error() string {
+	answers := make([]string, 0, len(e.counts))
+	for answer := range e.counts {
+		answers = append(answers, answer)
+	}
+	sort.Slice(answers, func(i, j int) bool {
+		if e.counts[answers[i]] != e.counts[answers[j]] {
+			return e.counts[answers[i]] > e.counts[answers[j]]
+		}
+		return answers[i] < answers[j]
+	})
+
+	described := make([]string, len(answers))
+	for i, answer := range answers {
+		if strings.Contains(answer, "\n") {
+			answer = strconv.Quote(answer)
+		}
+		described[i] = fmt.Sprintf("%s (%dx)", answer, e.counts[answers[i]])
+	}
+	return fmt.Sprintf(
+		"nondeterministic: %d different answers in %d runs: %s",
+		len(answers),
+		e.runs,
+		strings.Join(described, ", "),
+	)
+}
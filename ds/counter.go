@@ -0,0 +1,66 @@
+package ds
+
+// Counter counts occurrences of items. Make one with NewCounter.
+type Counter[T comparable] map[T]int
+
+// NewCounter returns a counter with one count for each of items.
+func NewCounter[T comparable](items ...T) Counter[T] {
+	c := Counter[T]{}
+	c.Add(items...)
+	return c
+}
+
+// Add counts each of items once more.
+func (c Counter[T]) Add(items ...T) {
+	for _, item := range items {
+		c[item]++
+	}
+}
+
+// Update adds the counts in other to c.
+func (c Counter[T]) Update(other Counter[T]) {
+	for item, n := range other {
+		c[item] += n
+	}
+}
+
+func (c Counter[T]) Clone() Counter[T] {
+	clone := make(Counter[T], len(c))
+	for item, n := range c {
+		clone[item] = n
+	}
+	return clone
+}
+
+// Total returns the sum of all the counts.
+func (c Counter[T]) Total() int {
+	total := 0
+	for _, n := range c {
+		total += n
+	}
+	return total
+}
+
+// MostCommon returns the item with the highest count, and that count. Of
+// items with equal counts, any one may be returned. It returns false for
+// an empty counter.
+func (c Counter[T]) MostCommon() (T, int, bool) {
+	return c.extreme(func(a, b int) bool { return a > b })
+}
+
+// LeastCommon returns the item with the lowest count, and that count, as
+// for MostCommon.
+func (c Counter[T]) LeastCommon() (T, int, bool) {
+	return c.extreme(func(a, b int) bool { return a < b })
+}
+
+func (c Counter[T]) extreme(better func(a, b int) bool) (T, int, bool) {
+	var best T
+	bestN, found := 0, false
+	for item, n := range c {
+		if !found || better(n, bestN) {
+			best, bestN, found = item, n, true
+		}
+	}
+	return best, bestN, found
+}
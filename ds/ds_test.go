@@ -0,0 +1,195 @@
+package ds
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestDeque(t *testing.T) {
+	var d Deque[int]
+	if _, ok := d.PopFront(); ok {
+		t.Error("PopFront of an empty deque reported true")
+	}
+	if _, ok := d.PopBack(); ok {
+		t.Error("PopBack of an empty deque reported true")
+	}
+
+	// Pushing at both ends wraps the front around the end of the buffer,
+	// and grows it several times.
+	for i := 1; i <= 10; i++ {
+		d.PushFront(-i)
+		d.PushBack(i)
+	}
+	if d.Len() != 20 {
+		t.Fatalf("got length %d, want 20", d.Len())
+	}
+	if front, _ := d.Front(); front != -10 {
+		t.Errorf("got front %d, want -10", front)
+	}
+	if back, _ := d.Back(); back != 10 {
+		t.Errorf("got back %d, want 10", back)
+	}
+
+	var got []int
+	for d.Len() > 0 {
+		front, _ := d.PopFront()
+		back, _ := d.PopBack()
+		got = append(got, front, back)
+	}
+	want := []int{-10, 10, -9, 9, -8, 8, -7, 7, -6, 6, -5, 5, -4, 4, -3, 3, -2, 2, -1, 1}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestDequeWrapsWithoutGrowing(t *testing.T) {
+	var d Deque[int]
+	for i := 0; i < 3; i++ {
+		d.PushBack(i)
+	}
+	capacity := len(d.items)
+
+	// Moving through the buffer a step at a time wraps around it many
+	// times while never holding more than it did to start with.
+	var got []int
+	for i := 3; i < 20; i++ {
+		v, _ := d.PopFront()
+		got = append(got, v)
+		d.PushBack(i)
+	}
+	if len(d.items) != capacity {
+		t.Errorf("buffer grew from %d to %d", capacity, len(d.items))
+	}
+	for d.Len() > 0 {
+		v, _ := d.PopFront()
+		got = append(got, v)
+	}
+	for i, v := range got {
+		if v != i {
+			t.Fatalf("got %v, want 0 through 19 in order", got)
+		}
+	}
+}
+
+func TestStack(t *testing.T) {
+	var s Stack[*int]
+	one, two := 1, 2
+	s.Push(&one, &two)
+
+	if top, _ := s.Peek(); top != &two {
+		t.Errorf("got top %v, want %v", top, &two)
+	}
+	if top, _ := s.Pop(); top != &two {
+		t.Errorf("got popped %v, want %v", top, &two)
+	}
+	if s.items[:2][1] != nil {
+		t.Error("Pop left the item in the stack's backing array")
+	}
+	if top, _ := s.Pop(); top != &one {
+		t.Errorf("got popped %v, want %v", top, &one)
+	}
+	if _, ok := s.Pop(); ok {
+		t.Error("Pop of an empty stack reported true")
+	}
+}
+
+func TestQueue(t *testing.T) {
+	var q Queue[string]
+	q.Push("a", "b")
+	q.Push("c")
+
+	var got []string
+	for q.Len() > 0 {
+		v, _ := q.Pop()
+		got = append(got, v)
+	}
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if _, ok := q.Peek(); ok {
+		t.Error("Peek of an empty queue reported true")
+	}
+}
+
+func TestPriorityQueue(t *testing.T) {
+	pq := NewPriorityQueue(func(a, b int) bool { return a < b })
+	if _, ok := pq.Peek(); ok {
+		t.Error("Peek of an empty queue reported true")
+	}
+
+	pq.Push(5, 1, 4)
+	pq.Push(2, 3)
+	if min, _ := pq.Peek(); min != 1 {
+		t.Errorf("got peek %d, want 1", min)
+	}
+
+	var got []int
+	for pq.Len() > 0 {
+		v, _ := pq.Pop()
+		got = append(got, v)
+	}
+	if want := []int{1, 2, 3, 4, 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if _, ok := pq.Pop(); ok {
+		t.Error("Pop of an empty queue reported true")
+	}
+}
+
+func sorted(s Set[int]) []int {
+	items := s.Items()
+	sort.Ints(items)
+	return items
+}
+
+func TestSet(t *testing.T) {
+	a := NewSet(1, 2, 3)
+	b := NewSet(3, 4)
+
+	tests := []struct {
+		name string
+		got  Set[int]
+		want []int
+	}{
+		{"Union", a.Union(b), []int{1, 2, 3, 4}},
+		{"Intersection", a.Intersection(b), []int{3}},
+		{"Difference", a.Difference(b), []int{1, 2}},
+		{"Clone", a.Clone(), []int{1, 2, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sorted(tt.got); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got := sorted(a); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Errorf("set operations changed the set to %v", got)
+	}
+
+	a.Remove(1)
+	a.Add(5)
+	if a.Contains(1) || !a.Contains(5) || a.Len() != 3 {
+		t.Errorf("got %v after removing 1 and adding 5", sorted(a))
+	}
+}
+
+func TestCounter(t *testing.T) {
+	c := NewCounter("a", "b", "b", "c", "c", "c")
+	c.Update(NewCounter("a", "a", "a", "a"))
+
+	if item, n, _ := c.MostCommon(); item != "a" || n != 5 {
+		t.Errorf("got most common %s (%d), want a (5)", item, n)
+	}
+	if item, n, _ := c.LeastCommon(); item != "b" || n != 2 {
+		t.Errorf("got least common %s (%d), want b (2)", item, n)
+	}
+	if total := c.Total(); total != 10 {
+		t.Errorf("got total %d, want 10", total)
+	}
+	if _, _, ok := NewCounter[string]().MostCommon(); ok {
+		t.Error("MostCommon of an empty counter reported true")
+	}
+}
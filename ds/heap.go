@@ -0,0 +1,76 @@
+package ds
+
+import (
+	"container/heap"
+)
+
+// PriorityQueue is a list whose items come out in order, smallest first.
+// Make one with NewPriorityQueue.
+type PriorityQueue[T any] struct {
+	h *items[T]
+}
+
+// NewPriorityQueue returns an empty priority queue ordered by less.
+func NewPriorityQueue[T any](less func(a, b T) bool) *PriorityQueue[T] {
+	return &PriorityQueue[T]{&items[T]{less: less}}
+}
+
+func (pq *PriorityQueue[T]) Push(items ...T) {
+	for _, item := range items {
+		heap.Push(pq.h, item)
+	}
+}
+
+// Pop removes and returns the smallest item, or false if the queue is
+// empty.
+func (pq *PriorityQueue[T]) Pop() (T, bool) {
+	if pq.Len() == 0 {
+		var zero T
+		return zero, false
+	}
+	return heap.Pop(pq.h).(T), true
+}
+
+// Peek returns the smallest item without removing it.
+func (pq *PriorityQueue[T]) Peek() (T, bool) {
+	if pq.Len() == 0 {
+		var zero T
+		return zero, false
+	}
+	return pq.h.items[0], true
+}
+
+func (pq *PriorityQueue[T]) Len() int {
+	return len(pq.h.items)
+}
+
+// items implements heap.Interface.
+type items[T any] struct {
+	items []T
+	less  func(a, b T) bool
+}
+
+func (h items[T]) Len() int {
+	return len(h.items)
+}
+
+func (h items[T]) Less(i, j int) bool {
+	return h.less(h.items[i], h.items[j])
+}
+
+func (h items[T]) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+}
+
+func (h *items[T]) Push(x any) {
+	h.items = append(h.items, x.(T))
+}
+
+func (h *items[T]) Pop() any {
+	n := len(h.items) - 1
+	item := h.items[n]
+	var zero T
+	h.items[n] = zero
+	h.items = h.items[:n]
+	return item
+}
@@ -0,0 +1,85 @@
+// Package ds provides the generic containers that puzzles keep reaching
+// for: sets, counters, stacks, queues, deques and priority queues.
+package ds
+
+// Set is an unordered collection of distinct items. Make one with NewSet.
+type Set[T comparable] map[T]struct{}
+
+// NewSet returns a set holding items.
+func NewSet[T comparable](items ...T) Set[T] {
+	s := Set[T]{}
+	s.Add(items...)
+	return s
+}
+
+// Add puts items in the set.
+func (s Set[T]) Add(items ...T) {
+	for _, item := range items {
+		s[item] = struct{}{}
+	}
+}
+
+// Remove takes items out of the set.
+func (s Set[T]) Remove(items ...T) {
+	for _, item := range items {
+		delete(s, item)
+	}
+}
+
+// Contains reports whether item is in the set.
+func (s Set[T]) Contains(item T) bool {
+	_, ok := s[item]
+	return ok
+}
+
+func (s Set[T]) Len() int {
+	return len(s)
+}
+
+// Items returns the items in the set, in no particular order.
+func (s Set[T]) Items() []T {
+	items := make([]T, 0, len(s))
+	for item := range s {
+		items = append(items, item)
+	}
+	return items
+}
+
+func (s Set[T]) Clone() Set[T] {
+	c := make(Set[T], len(s))
+	for item := range s {
+		c[item] = struct{}{}
+	}
+	return c
+}
+
+// Union returns a new set of the items in either s or other.
+func (s Set[T]) Union(other Set[T]) Set[T] {
+	u := s.Clone()
+	for item := range other {
+		u[item] = struct{}{}
+	}
+	return u
+}
+
+// Intersection returns a new set of the items in both s and other.
+func (s Set[T]) Intersection(other Set[T]) Set[T] {
+	i := Set[T]{}
+	for item := range s {
+		if other.Contains(item) {
+			i[item] = struct{}{}
+		}
+	}
+	return i
+}
+
+// Difference returns a new set of the items in s but not in other.
+func (s Set[T]) Difference(other Set[T]) Set[T] {
+	d := Set[T]{}
+	for item := range s {
+		if !other.Contains(item) {
+			d[item] = struct{}{}
+		}
+	}
+	return d
+}
@@ -0,0 +1,143 @@
+package ds
+
+// Stack is a last-in, first-out list. The zero value is an empty stack.
+type Stack[T any] struct {
+	items []T
+}
+
+func (s *Stack[T]) Push(items ...T) {
+	s.items = append(s.items, items...)
+}
+
+// Pop removes and returns the item on top of the stack, or false if it is
+// empty.
+func (s *Stack[T]) Pop() (T, bool) {
+	item, ok := s.Peek()
+	if ok {
+		// Clear the slot, so the stack doesn't keep the item alive.
+		var zero T
+		s.items[len(s.items)-1] = zero
+		s.items = s.items[:len(s.items)-1]
+	}
+	return item, ok
+}
+
+// Peek returns the item on top of the stack without removing it.
+func (s *Stack[T]) Peek() (T, bool) {
+	if len(s.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return s.items[len(s.items)-1], true
+}
+
+func (s *Stack[T]) Len() int {
+	return len(s.items)
+}
+
+// Queue is a first-in, first-out list. The zero value is an empty queue.
+type Queue[T any] struct {
+	d Deque[T]
+}
+
+func (q *Queue[T]) Push(items ...T) {
+	for _, item := range items {
+		q.d.PushBack(item)
+	}
+}
+
+// Pop removes and returns the item at the front of the queue, or false if
+// it is empty.
+func (q *Queue[T]) Pop() (T, bool) {
+	return q.d.PopFront()
+}
+
+// Peek returns the item at the front of the queue without removing it.
+func (q *Queue[T]) Peek() (T, bool) {
+	return q.d.Front()
+}
+
+func (q *Queue[T]) Len() int {
+	return q.d.Len()
+}
+
+// Deque is a list that items can be added to and removed from at either
+// end. The zero value is an empty deque.
+type Deque[T any] struct {
+	// items is a ring buffer, holding n items starting at head.
+	items []T
+	head  int
+	n     int
+}
+
+func (d *Deque[T]) PushBack(item T) {
+	d.grow()
+	d.items[(d.head+d.n)%len(d.items)] = item
+	d.n++
+}
+
+func (d *Deque[T]) PushFront(item T) {
+	d.grow()
+	d.head = (d.head - 1 + len(d.items)) % len(d.items)
+	d.items[d.head] = item
+	d.n++
+}
+
+// PopFront removes and returns the first item, or false if the deque is
+// empty.
+func (d *Deque[T]) PopFront() (T, bool) {
+	item, ok := d.Front()
+	if ok {
+		var zero T
+		d.items[d.head] = zero
+		d.head = (d.head + 1) % len(d.items)
+		d.n--
+	}
+	return item, ok
+}
+
+// PopBack removes and returns the last item, or false if the deque is
+// empty.
+func (d *Deque[T]) PopBack() (T, bool) {
+	item, ok := d.Back()
+	if ok {
+		var zero T
+		d.items[(d.head+d.n-1)%len(d.items)] = zero
+		d.n--
+	}
+	return item, ok
+}
+
+// Front returns the first item without removing it.
+func (d *Deque[T]) Front() (T, bool) {
+	if d.n == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.items[d.head], true
+}
+
+// Back returns the last item without removing it.
+func (d *Deque[T]) Back() (T, bool) {
+	if d.n == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.items[(d.head+d.n-1)%len(d.items)], true
+}
+
+func (d *Deque[T]) Len() int {
+	return d.n
+}
+
+// grow makes room for one more item.
+func (d *Deque[T]) grow() {
+	if d.n < len(d.items) {
+		return
+	}
+	items := make([]T, 2*len(d.items)+1)
+	for i := 0; i < d.n; i++ {
+		items[i] = d.items[(d.head+i)%len(d.items)]
+	}
+	d.items, d.head = items, 0
+}
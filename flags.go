@@ -0,0 +1,136 @@
+package aoc
+
+import (
+	"flag"
+	"fmt"
+)
+
+// Flagger is an optional interface for solvers with settings of their own,
+// like the number of steps to simulate. Flags registers them on fs, to be
+// parsed along with the runner's flags. Zero values should leave the solver
+// solving the puzzle as written.
+type Flagger interface {
+	Flags(fs *flag.FlagSet)
+}
+
+// partFlag is the -part flag, where 0 stands for both parts.
+type partFlag int
+
+func (p *partFlag) String() string {
+	if p == nil || *p == 0 {
+		return "both"
+	}
+	return fmt.Sprint(int(*p))
+}
+
+func (p *partFlag) Set(s string) error {
+	switch s {
+	case "1":
+		*p = 1
+	case "2":
+		*p = 2
+	case "both":
+		*p = 0
+	default:
+		return fmt.Errorf("must be 1, 2 or both")
+	}
+	return nil
+}
+
+// SolverFlags are the flags of the solvers of some puzzles, registered on a
+// FlagSet, along with the puzzles that declared each of them.
+type SolverFlags struct {
+	fs     *flag.FlagSet
+	owners map[string][]puzzleKey
+}
+
+// RegisterSolverFlags registers the flags of each puzzle whose solver
+// implements Flagger on fs. Puzzles declaring the same flag name share a
+// single flag, which sets all of their values, so every puzzle can be run
+// together. Their usage is prefixed with the day each applies to.
+func RegisterSolverFlags(fs *flag.FlagSet, puzzles ...Puzzle) (SolverFlags, error) {
+	sf := SolverFlags{fs: fs, owners: map[string][]puzzleKey{}}
+	shared := map[string]*sharedFlag{}
+	for _, p := range puzzles {
+		f, ok := p.Solver.(Flagger)
+		if !ok {
+			continue
+		}
+
+		own := flag.NewFlagSet("", flag.ContinueOnError)
+		f.Flags(own)
+
+		var err error
+		own.VisitAll(func(fl *flag.Flag) {
+			usage := fmt.Sprintf("Day %d: %s", p.Day, fl.Usage)
+
+			sh, ok := shared[fl.Name]
+			switch {
+			case !ok && fs.Lookup(fl.Name) != nil:
+				err = fmt.Errorf("flag -%s of %s is already defined", fl.Name, p)
+				return
+			case !ok:
+				sh = &sharedFlag{}
+				shared[fl.Name] = sh
+				fs.Var(sh, fl.Name, usage)
+			case isBoolFlag(fl.Value) != sh.IsBoolFlag():
+				err = fmt.Errorf("flag -%s of %s doesn't match the same flag of other days", fl.Name, p)
+				return
+			default:
+				fs.Lookup(fl.Name).Usage += "; " + usage
+			}
+
+			*sh = append(*sh, fl.Value)
+			sf.owners[fl.Name] = append(sf.owners[fl.Name], puzzleKey{p.Year, p.Day})
+		})
+		if err != nil {
+			return SolverFlags{}, err
+		}
+	}
+	return sf, nil
+}
+
+// Unverified reports whether any of the flags declared by p's solver were
+// set, changing the puzzle it solves. It suits Options.Unverified.
+func (sf SolverFlags) Unverified(p Puzzle) bool {
+	set := false
+	sf.fs.Visit(func(f *flag.Flag) {
+		for _, owner := range sf.owners[f.Name] {
+			if owner == (puzzleKey{p.Year, p.Day}) {
+				set = true
+			}
+		}
+	})
+	return set
+}
+
+// sharedFlag is a flag declared by one or more solvers, setting the value
+// of each.
+type sharedFlag []flag.Value
+
+func (f *sharedFlag) String() string {
+	if f == nil || len(*f) == 0 {
+		return ""
+	}
+	return (*f)[0].String()
+}
+
+func (f *sharedFlag) Set(s string) error {
+	for _, v := range *f {
+		if err := v.Set(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *sharedFlag) IsBoolFlag() bool {
+	return len(*f) > 0 && isBoolFlag((*f)[0])
+}
+
+// isBoolFlag reports whether v is a flag that needs no value, like those
+// defined by flag.Bool.
+func isBoolFlag(v flag.Value) bool {
+	b, ok := v.(interface{ IsBoolFlag() bool })
+	return ok && b.IsBoolFlag()
+}
@@ -0,0 +1,124 @@
+package aoc
+
+import (
+	"flag"
+	"strings"
+	"testing"
+)
+
+// flagged is a solver that declares the flags registered by flags.
+type flagged struct {
+	flags func(fs *flag.FlagSet)
+}
+
+func (flagged) Solve1(input []string) (Answer, error) { return nil, nil }
+func (flagged) Solve2(input []string) (Answer, error) { return nil, nil }
+
+func (f flagged) Flags(fs *flag.FlagSet) { f.flags(fs) }
+
+func TestSolverFlags(t *testing.T) {
+	var steps1, steps2 int
+	var verbose bool
+	puzzles := []Puzzle{
+		{Year: 2021, Day: 1, Solver: flagged{func(fs *flag.FlagSet) {
+			fs.IntVar(&steps1, "steps", 0, "Steps to simulate")
+		}}},
+		{Year: 2021, Day: 2, Solver: flagged{func(fs *flag.FlagSet) {
+			fs.IntVar(&steps2, "steps", 0, "Steps to take")
+		}}},
+		{Year: 2021, Day: 3, Solver: flagged{func(fs *flag.FlagSet) {
+			fs.BoolVar(&verbose, "verbose", false, "Show the grid")
+		}}},
+		{Year: 2021, Day: 4, Solver: flagged{func(fs *flag.FlagSet) {}}},
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		steps      int
+		verbose    bool
+		unverified []int
+	}{
+		{"no flags", nil, 0, false, nil},
+		{"shared flag", []string{"-steps", "5"}, 5, false, []int{1, 2}},
+		{"own flag", []string{"-verbose"}, 0, true, []int{3}},
+		{"both", []string{"-steps=3", "-verbose"}, 3, true, []int{1, 2, 3}},
+		{"runner flag", []string{"-input", "example.txt"}, 0, false, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			steps1, steps2, verbose = 0, 0, false
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			var opts Options
+			opts.RegisterFlags(fs)
+			sf, err := RegisterSolverFlags(fs, puzzles...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := fs.Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+
+			if steps1 != tt.steps || steps2 != tt.steps {
+				t.Errorf("got steps %d and %d, want both %d", steps1, steps2, tt.steps)
+			}
+			if verbose != tt.verbose {
+				t.Errorf("got verbose %t, want %t", verbose, tt.verbose)
+			}
+			for _, p := range puzzles {
+				want := false
+				for _, day := range tt.unverified {
+					want = want || day == p.Day
+				}
+				if got := sf.Unverified(p); got != want {
+					t.Errorf("day %d: got unverified %t, want %t", p.Day, got, want)
+				}
+			}
+		})
+	}
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	if _, err := RegisterSolverFlags(fs, puzzles...); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := fs.Lookup("steps").Usage, "Day 1: Steps to simulate; Day 2: Steps to take"; got != want {
+		t.Errorf("got usage %q, want %q", got, want)
+	}
+}
+
+func TestSolverFlagsConflicts(t *testing.T) {
+	intFlag := flagged{func(fs *flag.FlagSet) { fs.Int("steps", 0, "") }}
+	boolFlag := flagged{func(fs *flag.FlagSet) { fs.Bool("steps", false, "") }}
+	inputFlag := flagged{func(fs *flag.FlagSet) { fs.String("input", "", "") }}
+
+	tests := []struct {
+		name    string
+		solvers []Solver
+		want    string
+	}{
+		{"int then bool", []Solver{intFlag, boolFlag}, "flag -steps of 2021 Day 2: Test doesn't match"},
+		{"bool then int", []Solver{boolFlag, intFlag}, "flag -steps of 2021 Day 2: Test doesn't match"},
+		{"runner flag", []Solver{inputFlag}, "flag -input of 2021 Day 1: Test is already defined"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			var opts Options
+			opts.RegisterFlags(fs)
+
+			var puzzles []Puzzle
+			for i, s := range tt.solvers {
+				puzzles = append(puzzles, Puzzle{Year: 2021, Day: i + 1, Title: "Test", Solver: s})
+			}
+			_, err := RegisterSolverFlags(fs, puzzles...)
+			if err == nil {
+				t.Fatal("got no error")
+			}
+			if !strings.HasPrefix(err.Error(), tt.want) {
+				t.Errorf("got error %q, want it to start with %q", err, tt.want)
+			}
+		})
+	}
+}
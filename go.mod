@@ -1,5 +1,5 @@
 module github.com/bclarkx2/aoc
 
-go 1.17
+go 1.18
 
-require github.com/peterbourgon/ff/v3 v3.1.2 // indirect
+require github.com/peterbourgon/ff/v3 v3.1.2
@@ -0,0 +1,240 @@
+package aoc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Grid is a rectangle of cells, addressed by column x and row y from the
+// top left corner.
+type Grid[T any] struct {
+	width  int
+	height int
+	cells  []T
+}
+
+// NewGrid returns a grid of zero-valued cells.
+func NewGrid[T any](width, height int) *Grid[T] {
+	return &Grid[T]{
+		width:  width,
+		height: height,
+		cells:  make([]T, width*height),
+	}
+}
+
+// ParseGrid reads a grid with a cell for each character of the input,
+// converted by parse. Every line must be the same length.
+func ParseGrid[T any](input []string, parse func(r rune) (T, error)) (*Grid[T], error) {
+	if len(input) == 0 {
+		return NewGrid[T](0, 0), nil
+	}
+
+	g := NewGrid[T](len([]rune(input[0])), len(input))
+	for y, line := range input {
+		runes := []rune(line)
+		if len(runes) != g.width {
+			return nil, fmt.Errorf("line %d is %d wide, want %d", y+1, len(runes), g.width)
+		}
+		for x, r := range runes {
+			v, err := parse(r)
+			if err != nil {
+				return nil, fmt.Errorf("line %d column %d: %w", y+1, x+1, err)
+			}
+			g.cells[y*g.width+x] = v
+		}
+	}
+	return g, nil
+}
+
+// DigitGrid reads a grid of single digits.
+func DigitGrid(input []string) (*Grid[int], error) {
+	return ParseGrid(input, func(r rune) (int, error) {
+		if r < '0' || r > '9' {
+			return 0, fmt.Errorf("%q is not a digit", r)
+		}
+		return int(r - '0'), nil
+	})
+}
+
+// RuneGrid reads a grid of characters.
+func RuneGrid(input []string) (*Grid[rune], error) {
+	return ParseGrid(input, func(r rune) (rune, error) {
+		return r, nil
+	})
+}
+
+func (g *Grid[T]) Width() int {
+	return g.width
+}
+
+func (g *Grid[T]) Height() int {
+	return g.height
+}
+
+// In reports whether x, y is a cell of the grid.
+func (g *Grid[T]) In(x, y int) bool {
+	return x >= 0 && x < g.width && y >= 0 && y < g.height
+}
+
+// Get returns the cell at x, y, or false if it lies outside the grid.
+func (g *Grid[T]) Get(x, y int) (T, bool) {
+	if !g.In(x, y) {
+		var zero T
+		return zero, false
+	}
+	return g.cells[y*g.width+x], true
+}
+
+// At returns the cell at x, y, which must lie inside the grid.
+func (g *Grid[T]) At(x, y int) T {
+	if !g.In(x, y) {
+		panic(fmt.Sprintf("aoc: %d,%d is outside the %dx%d grid", x, y, g.width, g.height))
+	}
+	return g.cells[y*g.width+x]
+}
+
+// Set changes the cell at x, y, reporting false if it lies outside the
+// grid.
+func (g *Grid[T]) Set(x, y int, v T) bool {
+	if !g.In(x, y) {
+		return false
+	}
+	g.cells[y*g.width+x] = v
+	return true
+}
+
+// Each calls f for every cell, row by row.
+func (g *Grid[T]) Each(f func(x, y int, v T)) {
+	for y := 0; y < g.height; y++ {
+		for x := 0; x < g.width; x++ {
+			f(x, y, g.cells[y*g.width+x])
+		}
+	}
+}
+
+// Neighbors4 calls f for each cell above, right of, below and left of x, y
+// that lies inside the grid.
+func (g *Grid[T]) Neighbors4(x, y int, f func(x, y int, v T)) {
+	g.neighbors(x, y, Directions4, f)
+}
+
+// Neighbors8 calls f for each of the eight cells around x, y, including
+// diagonals, that lies inside the grid.
+func (g *Grid[T]) Neighbors8(x, y int, f func(x, y int, v T)) {
+	g.neighbors(x, y, Directions8, f)
+}
+
+func (g *Grid[T]) neighbors(x, y int, directions []Point2, f func(x, y int, v T)) {
+	for _, d := range directions {
+		nx, ny := x+d.X, y+d.Y
+		if g.In(nx, ny) {
+			f(nx, ny, g.cells[ny*g.width+nx])
+		}
+	}
+}
+
+// Row returns a copy of row y.
+func (g *Grid[T]) Row(y int) []T {
+	row := make([]T, g.width)
+	copy(row, g.cells[y*g.width:(y+1)*g.width])
+	return row
+}
+
+// Column returns a copy of column x.
+func (g *Grid[T]) Column(x int) []T {
+	column := make([]T, g.height)
+	for y := range column {
+		column[y] = g.cells[y*g.width+x]
+	}
+	return column
+}
+
+// Clone returns a copy of the grid.
+func (g *Grid[T]) Clone() *Grid[T] {
+	c := NewGrid[T](g.width, g.height)
+	copy(c.cells, g.cells)
+	return c
+}
+
+// remap returns a new width by height grid, where each cell x, y is taken
+// from the cell of g at from(x, y).
+func (g *Grid[T]) remap(width, height int, from func(x, y int) (int, int)) *Grid[T] {
+	r := NewGrid[T](width, height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			fx, fy := from(x, y)
+			r.cells[y*width+x] = g.cells[fy*g.width+fx]
+		}
+	}
+	return r
+}
+
+// Transpose returns a copy of the grid with its rows and columns swapped.
+func (g *Grid[T]) Transpose() *Grid[T] {
+	return g.remap(g.height, g.width, func(x, y int) (int, int) {
+		return y, x
+	})
+}
+
+// RotateClockwise returns a copy of the grid turned a quarter turn
+// clockwise.
+func (g *Grid[T]) RotateClockwise() *Grid[T] {
+	return g.remap(g.height, g.width, func(x, y int) (int, int) {
+		return y, g.height - 1 - x
+	})
+}
+
+// RotateCounterClockwise returns a copy of the grid turned a quarter turn
+// counter-clockwise.
+func (g *Grid[T]) RotateCounterClockwise() *Grid[T] {
+	return g.remap(g.height, g.width, func(x, y int) (int, int) {
+		return g.width - 1 - y, x
+	})
+}
+
+// FlipHorizontal returns a copy of the grid mirrored left to right.
+func (g *Grid[T]) FlipHorizontal() *Grid[T] {
+	return g.remap(g.width, g.height, func(x, y int) (int, int) {
+		return g.width - 1 - x, y
+	})
+}
+
+// FlipVertical returns a copy of the grid mirrored top to bottom.
+func (g *Grid[T]) FlipVertical() *Grid[T] {
+	return g.remap(g.width, g.height, func(x, y int) (int, int) {
+		return x, g.height - 1 - y
+	})
+}
+
+// Render draws the grid as text, a line per row, with each cell drawn by
+// cell.
+func (g *Grid[T]) Render(cell func(v T) string) string {
+	var b strings.Builder
+	for y := 0; y < g.height; y++ {
+		if y > 0 {
+			b.WriteByte('\n')
+		}
+		for x := 0; x < g.width; x++ {
+			b.WriteString(cell(g.cells[y*g.width+x]))
+		}
+	}
+	return b.String()
+}
+
+// String draws the grid as text. Runes are drawn as themselves, booleans
+// as '#' and '.', and anything else as formatted by fmt.
+func (g *Grid[T]) String() string {
+	return g.Render(func(v T) string {
+		switch c := any(v).(type) {
+		case rune:
+			return string(c)
+		case bool:
+			if c {
+				return "#"
+			}
+			return "."
+		default:
+			return fmt.Sprint(c)
+		}
+	})
+}
@@ -0,0 +1,94 @@
+package aoc
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestGridTransforms(t *testing.T) {
+	g, err := RuneGrid([]string{
+		"abc",
+		"def",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		got  *Grid[rune]
+		want string
+	}{
+		{"Clone", g.Clone(), "abc\ndef"},
+		{"Transpose", g.Transpose(), "ad\nbe\ncf"},
+		{"RotateClockwise", g.RotateClockwise(), "da\neb\nfc"},
+		{"RotateCounterClockwise", g.RotateCounterClockwise(), "cf\nbe\nad"},
+		{"FlipHorizontal", g.FlipHorizontal(), "cba\nfed"},
+		{"FlipVertical", g.FlipVertical(), "def\nabc"},
+		{"full turn", g.RotateClockwise().RotateClockwise().RotateClockwise().RotateClockwise(), "abc\ndef"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got.String(); got != tt.want {
+				t.Errorf("got\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+
+	if got := g.String(); got != "abc\ndef" {
+		t.Errorf("transforms changed the original grid to\n%s", got)
+	}
+}
+
+func TestGridRowsAndColumns(t *testing.T) {
+	g, err := DigitGrid([]string{
+		"123",
+		"456",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := fmt.Sprint(g.Row(1)), "[4 5 6]"; got != want {
+		t.Errorf("got row %s, want %s", got, want)
+	}
+	if got, want := fmt.Sprint(g.Column(2)), "[3 6]"; got != want {
+		t.Errorf("got column %s, want %s", got, want)
+	}
+
+	// Copies don't share cells with the grid.
+	g.Row(0)[0] = 9
+	g.Column(0)[0] = 9
+	if got := g.At(0, 0); got != 1 {
+		t.Errorf("got cell %d after changing copies, want 1", got)
+	}
+}
+
+func TestGridRender(t *testing.T) {
+	g := NewGrid[bool](3, 2)
+	g.Set(0, 0, true)
+	g.Set(2, 1, true)
+	if g.Set(3, 0, true) {
+		t.Error("Set outside the grid reported true")
+	}
+
+	if got, want := g.String(), "#..\n..#"; got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+
+	got := g.Render(func(lit bool) string {
+		if lit {
+			return "[]"
+		}
+		return "  "
+	})
+	if want := "[]    \n    []"; got != want {
+		t.Errorf("got\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestParseGridRagged(t *testing.T) {
+	if _, err := DigitGrid([]string{"123", "45"}); err == nil {
+		t.Error("got no error for rows of different lengths")
+	}
+}
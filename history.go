@@ -0,0 +1,133 @@
+package aoc
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// DefaultHistory is the history file used by the aoc command, relative to
+// the directory it is run from.
+const DefaultHistory = ".aoc-history.jsonl"
+
+// Record is one timed run of a single part, as kept in the history file.
+type Record struct {
+	Time      time.Time     `json:"time"`
+	Year      int           `json:"year"`
+	Day       int           `json:"day"`
+	Part      int           `json:"part"`
+	Input     string        `json:"input"`
+	InputHash string        `json:"input_hash"`
+	Commit    string        `json:"commit,omitempty"`
+	Duration  time.Duration `json:"duration_ns"`
+	Allocs    uint64        `json:"allocs"`
+	Bytes     uint64        `json:"bytes"`
+
+	// Mode is how the part was timed: "bench" for the median of -bench
+	// runs, "determinism" for the first of -determinism runs, or empty for
+	// a single run.
+	Mode string `json:"mode,omitempty"`
+}
+
+// hashLines identifies an input by its contents, so that timings are only
+// compared between runs against the same input.
+func hashLines(lines []string) string {
+	sum := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+	return hex.EncodeToString(sum[:8])
+}
+
+// gitCommit returns the commit checked out in the working directory, or ""
+// outside of a git repository.
+func gitCommit() string {
+	out, err := exec.Command("git", "rev-parse", "--short", "HEAD").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// newRecords makes a history record for every execution that succeeded.
+// Failed executions are left out, since their timings say little about the
+// solver's performance.
+func newRecords(p Puzzle, executions []execution, commit, mode string, now time.Time) []Record {
+	var records []Record
+	for _, e := range executions {
+		if e.err != nil {
+			continue
+		}
+		records = append(records, Record{
+			Time:      now,
+			Year:      p.Year,
+			Day:       p.Day,
+			Part:      e.part,
+			Input:     e.input,
+			InputHash: e.inputHash,
+			Commit:    commit,
+			Mode:      mode,
+			Duration:  e.elapsed,
+			Allocs:    e.allocs,
+			Bytes:     e.bytes,
+		})
+	}
+	return records
+}
+
+// AppendHistory adds records to the history file at path, creating it if
+// necessary. The file holds one JSON record per line.
+func AppendHistory(path string, records []Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(f)
+	for _, r := range records {
+		if err := enc.Encode(r); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	return f.Close()
+}
+
+// LoadHistory reads every record from the history file at path, oldest
+// first. A missing file is an empty history.
+func LoadHistory(path string) ([]Record, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var records []Record
+	scanner := bufio.NewScanner(f)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var r Record
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, lineNum, err)
+		}
+		records = append(records, r)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
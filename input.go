@@ -0,0 +1,74 @@
+package aoc
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// expandInput resolves the -input flag to a list of input files. The flag
+// may name a single file, a glob pattern, or a directory, in which case
+// every .txt file in that directory is used.
+func expandInput(input string) ([]string, error) {
+	if input == Stdin {
+		return []string{input}, nil
+	}
+
+	info, err := os.Stat(input)
+	if err == nil && info.IsDir() {
+		input = filepath.Join(input, "*.txt")
+	} else if err == nil || !strings.ContainsAny(input, "*?[") {
+		return []string{input}, nil
+	}
+
+	matches, err := filepath.Glob(input)
+	if err != nil {
+		return nil, err
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("no input files match %s", input)
+	}
+
+	return matches, nil
+}
+
+// Stdin is the input file name that stands for standard input.
+const Stdin = "-"
+
+// maxLineLength bounds the length of a single input line. Some puzzles, like
+// 2021 day 16, have their whole input on one line.
+const maxLineLength = 1 << 30
+
+// ReadLines reads an input file, or standard input for Stdin, as a slice of
+// lines.
+func ReadLines(inputFile string) ([]string, error) {
+	if inputFile == Stdin {
+		return ReadLinesFrom(os.Stdin)
+	}
+
+	f, err := os.Open(inputFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ReadLinesFrom(f)
+}
+
+// ReadLinesFrom reads r to the end as a slice of lines.
+func ReadLinesFrom(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxLineLength)
+	var lines []string
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return lines, nil
+}
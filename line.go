@@ -0,0 +1,85 @@
+package aoc
+
+// Segment is the straight line from one point to another, including both
+// ends.
+type Segment struct {
+	From, To Point2
+}
+
+// Points returns every point with integer coordinates on the segment, in
+// order from From to To. Any slope works: a line from 0,0 to 4,2 passes
+// through 2,1 but not through 1,0 or 1,1.
+func (s Segment) Points() []Point2 {
+	d := s.To.Sub(s.From)
+	steps := GCD(d.X, d.Y)
+	if steps == 0 {
+		return []Point2{s.From}
+	}
+
+	step := Point2{d.X / steps, d.Y / steps}
+	points := make([]Point2, 0, steps+1)
+	for i := 0; i <= steps; i++ {
+		points = append(points, s.From.Add(step.Scale(i)))
+	}
+	return points
+}
+
+// Horizontal reports whether the segment runs along a row.
+func (s Segment) Horizontal() bool {
+	return s.From.Y == s.To.Y
+}
+
+// Vertical reports whether the segment runs along a column.
+func (s Segment) Vertical() bool {
+	return s.From.X == s.To.X
+}
+
+// Contains reports whether p lies on the segment.
+func (s Segment) Contains(p Point2) bool {
+	return orientation(s.From, s.To, p) == 0 && s.bounds(p)
+}
+
+// Intersects reports whether the segments share any point, including when
+// they only touch at an end or overlap along a shared line. The point
+// they share need not have integer coordinates.
+func (s Segment) Intersects(other Segment) bool {
+	o1 := orientation(s.From, s.To, other.From)
+	o2 := orientation(s.From, s.To, other.To)
+	o3 := orientation(other.From, other.To, s.From)
+	o4 := orientation(other.From, other.To, s.To)
+
+	if o1 != o2 && o3 != o4 {
+		return true
+	}
+
+	// Otherwise they only meet if an end of one lies on the other.
+	return s.Contains(other.From) || s.Contains(other.To) ||
+		other.Contains(s.From) || other.Contains(s.To)
+}
+
+// bounds reports whether p lies within the rectangle spanned by the
+// segment's ends.
+func (s Segment) bounds(p Point2) bool {
+	return between(p.X, s.From.X, s.To.X) && between(p.Y, s.From.Y, s.To.Y)
+}
+
+func between(n, a, b int) bool {
+	if a > b {
+		a, b = b, a
+	}
+	return a <= n && n <= b
+}
+
+// orientation is 1 if c is counterclockwise of the line from a to b, -1 if
+// it is clockwise, and 0 if the three points are in line.
+func orientation(a, b, c Point2) int {
+	ab, ac := b.Sub(a), c.Sub(a)
+	cross := ab.X*ac.Y - ab.Y*ac.X
+	switch {
+	case cross > 0:
+		return 1
+	case cross < 0:
+		return -1
+	}
+	return 0
+}
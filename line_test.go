@@ -0,0 +1,81 @@
+package aoc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func seg(x1, y1, x2, y2 int) Segment {
+	return Segment{Point2{x1, y1}, Point2{x2, y2}}
+}
+
+func TestSegmentPoints(t *testing.T) {
+	tests := []struct {
+		name string
+		s    Segment
+		want []Point2
+	}{
+		{"horizontal", seg(3, 1, 0, 1), []Point2{{3, 1}, {2, 1}, {1, 1}, {0, 1}}},
+		{"vertical", seg(2, 0, 2, 2), []Point2{{2, 0}, {2, 1}, {2, 2}}},
+		{"diagonal", seg(0, 2, 2, 0), []Point2{{0, 2}, {1, 1}, {2, 0}}},
+		{"shallow", seg(0, 0, 6, 4), []Point2{{0, 0}, {3, 2}, {6, 4}}},
+		{"no points between", seg(0, 0, 2, 3), []Point2{{0, 0}, {2, 3}}},
+		{"single point", seg(5, 5, 5, 5), []Point2{{5, 5}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.s.Points(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSegmentContains(t *testing.T) {
+	s := seg(0, 0, 6, 4)
+	tests := []struct {
+		p    Point2
+		want bool
+	}{
+		{Point2{0, 0}, true},
+		{Point2{3, 2}, true},
+		{Point2{6, 4}, true},
+		{Point2{1, 1}, false},
+		{Point2{9, 6}, false},
+		{Point2{-3, -2}, false},
+	}
+	for _, tt := range tests {
+		if got := s.Contains(tt.p); got != tt.want {
+			t.Errorf("%v contains %v: got %t, want %t", s, tt.p, got, tt.want)
+		}
+	}
+}
+
+func TestSegmentIntersects(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b Segment
+		want bool
+	}{
+		{"crossing", seg(0, 0, 4, 4), seg(0, 4, 4, 0), true},
+		{"crossing between lattice points", seg(0, 0, 1, 1), seg(0, 1, 1, 0), true},
+		{"touching at an end", seg(0, 0, 4, 4), seg(4, 4, 6, 0), true},
+		{"end on the other's middle", seg(0, 2, 4, 2), seg(2, 2, 2, 5), true},
+		{"collinear overlapping", seg(0, 0, 4, 4), seg(2, 2, 9, 9), true},
+		{"collinear one inside the other", seg(0, 0, 9, 0), seg(3, 0, 5, 0), true},
+		{"collinear separate", seg(0, 0, 2, 2), seg(3, 3, 5, 5), false},
+		{"parallel", seg(0, 0, 4, 2), seg(0, 1, 4, 3), false},
+		{"apart", seg(0, 0, 1, 1), seg(3, 0, 5, -4), false},
+		{"would cross if longer", seg(0, 0, 2, 2), seg(4, 0, 3, 1), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.Intersects(tt.b); got != tt.want {
+				t.Errorf("%v intersects %v: got %t, want %t", tt.a, tt.b, got, tt.want)
+			}
+			if got := tt.b.Intersects(tt.a); got != tt.want {
+				t.Errorf("%v intersects %v: got %t, want %t", tt.b, tt.a, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package aoc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+type loggerKey struct{}
+
+// Logf logs a message through the logger that Run puts in the context of
+// each part, so that solvers can describe what they are doing. Nothing is
+// logged, or even formatted, unless the -debug flag is set.
+func Logf(ctx context.Context, format string, v ...interface{}) {
+	if l, ok := ctx.Value(loggerKey{}).(*log.Logger); ok && l.Writer() != io.Discard {
+		l.Printf(format, v...)
+	}
+}
+
+// logger returns the logger for one part of a solver run against an input
+// file. Its output goes to standard error, so it doesn't interleave with the
+// results, with each line saying which input and part it came from.
+func (o Options) logger(inputFile string, part int) *log.Logger {
+	prefix := fmt.Sprintf("part %d: ", part)
+	if part == 0 {
+		prefix = "parse: "
+	}
+	if inputFile != "" {
+		prefix = filepath.ToSlash(inputFile) + " " + prefix
+	}
+	return log.New(os.Stderr, prefix, log.Lmsgprefix)
+}
+
+// withLogger wraps run to give each part a logger of its own, in its
+// context, when debug logs are enabled. Parts that are abandoned after a
+// timeout keep theirs, so late lines still say where they came from.
+func withLogger(run runner, opts Options, inputFile string) runner {
+	if !opts.Debug {
+		return run
+	}
+	return func(part int, f partFunc) execution {
+		l := opts.logger(inputFile, part)
+		return run(part, func(ctx context.Context) (Answer, error) {
+			return f(context.WithValue(ctx, loggerKey{}, l))
+		})
+	}
+}
@@ -0,0 +1,420 @@
+package aoc
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"strings"
+)
+
+// font is one of the block-letter alphabets that puzzles draw answers in.
+// Letters are drawn in cells of width+gap columns; most leave the gap blank,
+// but a few, like the small Y, are wide enough to fill it.
+type font struct {
+	width  int
+	height int
+	gap    int
+
+	glyphs map[string]rune
+}
+
+func newFont(width, height, gap int, letters map[rune]string) font {
+	f := font{
+		width:  width,
+		height: height,
+		gap:    gap,
+		glyphs: map[string]rune{},
+	}
+	for letter, glyph := range letters {
+		rows := strings.Split(strings.TrimSpace(glyph), "\n")
+		for i, row := range rows {
+			rows[i] = row + strings.Repeat(".", f.stride()-len(row))
+		}
+		f.glyphs[strings.Join(rows, "\n")] = letter
+	}
+	return f
+}
+
+func (f font) stride() int {
+	return f.width + f.gap
+}
+
+var smallFont = newFont(4, 6, 1, map[rune]string{
+	'A': `
+.##.
+#..#
+#..#
+####
+#..#
+#..#`,
+	'B': `
+###.
+#..#
+###.
+#..#
+#..#
+###.`,
+	'C': `
+.##.
+#..#
+#...
+#...
+#..#
+.##.`,
+	'E': `
+####
+#...
+###.
+#...
+#...
+####`,
+	'F': `
+####
+#...
+###.
+#...
+#...
+#...`,
+	'G': `
+.##.
+#..#
+#...
+#.##
+#..#
+.###`,
+	'H': `
+#..#
+#..#
+####
+#..#
+#..#
+#..#`,
+	'I': `
+.###
+..#.
+..#.
+..#.
+..#.
+.###`,
+	'J': `
+..##
+...#
+...#
+...#
+#..#
+.##.`,
+	'K': `
+#..#
+#.#.
+##..
+#.#.
+#.#.
+#..#`,
+	'L': `
+#...
+#...
+#...
+#...
+#...
+####`,
+	'O': `
+.##.
+#..#
+#..#
+#..#
+#..#
+.##.`,
+	'P': `
+###.
+#..#
+#..#
+###.
+#...
+#...`,
+	'R': `
+###.
+#..#
+#..#
+###.
+#.#.
+#..#`,
+	'S': `
+.###
+#...
+#...
+.##.
+...#
+###.`,
+	'U': `
+#..#
+#..#
+#..#
+#..#
+#..#
+.##.`,
+	'Y': `
+#...#
+#...#
+.#.#.
+..#..
+..#..
+..#..`,
+	'Z': `
+####
+...#
+..#.
+.#..
+#...
+####`,
+})
+
+var largeFont = newFont(6, 10, 2, map[rune]string{
+	'A': `
+..##..
+.#..#.
+#....#
+#....#
+#....#
+######
+#....#
+#....#
+#....#
+#....#`,
+	'B': `
+#####.
+#....#
+#....#
+#....#
+#####.
+#....#
+#....#
+#....#
+#....#
+#####.`,
+	'C': `
+.####.
+#....#
+#.....
+#.....
+#.....
+#.....
+#.....
+#.....
+#....#
+.####.`,
+	'E': `
+######
+#.....
+#.....
+#.....
+#####.
+#.....
+#.....
+#.....
+#.....
+######`,
+	'F': `
+######
+#.....
+#.....
+#.....
+#####.
+#.....
+#.....
+#.....
+#.....
+#.....`,
+	'G': `
+.####.
+#....#
+#.....
+#.....
+#.....
+#..###
+#....#
+#....#
+#...##
+.###.#`,
+	'H': `
+#....#
+#....#
+#....#
+#....#
+######
+#....#
+#....#
+#....#
+#....#
+#....#`,
+	'J': `
+...###
+....#.
+....#.
+....#.
+....#.
+....#.
+....#.
+#...#.
+#...#.
+.###..`,
+	'K': `
+#....#
+#...#.
+#..#..
+#.#...
+##....
+##....
+#.#...
+#..#..
+#...#.
+#....#`,
+	'L': `
+#.....
+#.....
+#.....
+#.....
+#.....
+#.....
+#.....
+#.....
+#.....
+######`,
+	'N': `
+#....#
+##...#
+##...#
+#.#..#
+#.#..#
+#..#.#
+#..#.#
+#...##
+#...##
+#....#`,
+	'P': `
+#####.
+#....#
+#....#
+#....#
+#####.
+#.....
+#.....
+#.....
+#.....
+#.....`,
+	'R': `
+#####.
+#....#
+#....#
+#....#
+#####.
+#..#..
+#...#.
+#...#.
+#....#
+#....#`,
+	'X': `
+#....#
+#....#
+.#..#.
+.#..#.
+..##..
+..##..
+.#..#.
+.#..#.
+#....#
+#....#`,
+	'Z': `
+######
+.....#
+.....#
+....#.
+...#..
+..#...
+.#....
+#.....
+#.....
+######`,
+})
+
+// OCR reads the capital letters drawn by a rendered grid, where '#' marks a
+// lit cell and any other character an unlit one. Both the 4x6 and the 6x10
+// block-letter fonts are recognized; blank rows and columns around the
+// letters are ignored.
+func OCR(rendered string) (string, error) {
+	var points []image.Point
+	for y, line := range strings.Split(rendered, "\n") {
+		for x, r := range []rune(line) {
+			if r == '#' {
+				points = append(points, image.Pt(x, y))
+			}
+		}
+	}
+	return OCRPoints(points)
+}
+
+// OCRPoints reads the capital letters drawn by a set of lit points.
+func OCRPoints(points []image.Point) (string, error) {
+	if len(points) == 0 {
+		return "", errors.New("ocr: no lit points")
+	}
+
+	lit := map[image.Point]bool{}
+	bounds := image.Rectangle{Min: points[0], Max: points[0]}
+	for _, p := range points {
+		lit[p] = true
+		bounds = bounds.Union(image.Rectangle{Min: p, Max: p.Add(image.Pt(1, 1))})
+	}
+
+	var f font
+	switch bounds.Dy() {
+	case smallFont.height:
+		f = smallFont
+	case largeFont.height:
+		f = largeFont
+	default:
+		return "", fmt.Errorf("ocr: no font is %d rows tall", bounds.Dy())
+	}
+
+	// Letters like I don't light their first column, so the leftmost lit
+	// point isn't always the start of a cell. Try each alignment of the cells
+	// and report the error from the one starting at the leftmost point.
+	var first error
+	for shift := 0; shift < f.stride(); shift++ {
+		letters, err := f.read(lit, bounds.Min.X-shift, bounds)
+		if err == nil {
+			return letters, nil
+		}
+		if first == nil {
+			first = err
+		}
+	}
+	return "", first
+}
+
+// read reads the letters in cells starting at column left.
+func (f font) read(lit map[image.Point]bool, left int, bounds image.Rectangle) (string, error) {
+	var letters []rune
+	for ; left < bounds.Max.X; left += f.stride() {
+		rows := make([]string, f.height)
+		for y := 0; y < f.height; y++ {
+			var row strings.Builder
+			for x := 0; x < f.stride(); x++ {
+				if lit[image.Pt(left+x, bounds.Min.Y+y)] {
+					row.WriteByte('#')
+				} else {
+					row.WriteByte('.')
+				}
+			}
+			rows[y] = row.String()
+		}
+
+		glyph := strings.Join(rows, "\n")
+		letter, ok := f.glyphs[glyph]
+		if !ok {
+			return "", fmt.Errorf("ocr: unrecognized letter %d:\n%s", len(letters)+1, glyph)
+		}
+		letters = append(letters, letter)
+	}
+
+	return string(letters), nil
+}
@@ -0,0 +1,119 @@
+package aoc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestOCR(t *testing.T) {
+	tests := []struct {
+		name     string
+		rendered string
+		want     string
+	}{
+		{
+			name: "small font",
+			rendered: `
+####.#..#...##
+#....#..#....#
+###..#..#....#
+#....#..#....#
+#....#..#.#..#
+#.....##...##.`,
+			want: "FUJ",
+		},
+		{
+			name: "small font with margins",
+			rendered: `
+.........
+..####...
+..#......
+..###....
+..#......
+..#......
+..#......
+.........`,
+			want: "F",
+		},
+		{
+			name: "small font I and Y",
+			rendered: `
+.###.#...##...
+..#..#...##...
+..#...#.#.#...
+..#....#..#...
+..#....#..#...
+.###...#..####`,
+			want: "IYL",
+		},
+		{
+			name: "large font",
+			rendered: `
+#....#..#....#..######
+##...#..#....#.......#
+##...#...#..#........#
+#.#..#...#..#.......#.
+#.#..#....##.......#..
+#..#.#....##......#...
+#..#.#...#..#....#....
+#...##...#..#...#.....
+#...##..#....#..#.....
+#....#..#....#..######`,
+			want: "NXZ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := OCR(strings.TrimPrefix(tt.rendered, "\n"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOCRErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		rendered string
+		want     string
+	}{
+		{
+			name:     "nothing lit",
+			rendered: "....\n....",
+			want:     "ocr: no lit points",
+		},
+		{
+			name:     "no font that tall",
+			rendered: "#\n#\n#",
+			want:     "ocr: no font is 3 rows tall",
+		},
+		{
+			name: "unrecognized letter",
+			rendered: `
+####.####
+#....#..#
+###..#..#
+#....#..#
+#....#..#
+#....####`,
+			want: "ocr: unrecognized letter 2:",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := OCR(strings.TrimPrefix(tt.rendered, "\n"))
+			if err == nil {
+				t.Fatal("got no error")
+			}
+			if !strings.HasPrefix(err.Error(), tt.want) {
+				t.Errorf("got error %q, want it to start with %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package aoc
+
+import (
+	"context"
+	"flag"
+	"fmt"
+)
+
+// Parser is an optional interface for solvers whose parts work from the
+// same parsed input. When a Solver also implements Parser, Run calls Parse
+// once per input file, times it separately from the parts, and hands the
+// result to SolveParsed1 and SolveParsed2. The parts must not modify the
+// parsed value, since both of them receive it.
+type Parser interface {
+	Parse(input []string) (interface{}, error)
+	SolveParsed1(parsed interface{}) (Answer, error)
+	SolveParsed2(parsed interface{}) (Answer, error)
+}
+
+// Parsed adapts a Parser to the Solver interface. The returned Solver
+// parses the input on every call to Solve1 or Solve2, but Run recognizes
+// it as a Parser and parses only once.
+func Parsed(p Parser) Solver {
+	return parsed{p}
+}
+
+type parsed struct {
+	Parser
+}
+
+func (p parsed) Solve1(input []string) (Answer, error) {
+	v, err := p.Parse(input)
+	if err != nil {
+		return nil, err
+	}
+	return p.SolveParsed1(v)
+}
+
+func (p parsed) Solve2(input []string) (Answer, error) {
+	v, err := p.Parse(input)
+	if err != nil {
+		return nil, err
+	}
+	return p.SolveParsed2(v)
+}
+
+// Flags registers the flags of the wrapped Parser, if it has any.
+func (p parsed) Flags(fs *flag.FlagSet) {
+	if f, ok := p.Parser.(Flagger); ok {
+		f.Flags(fs)
+	}
+}
+
+// ContextParser is a Parser whose parts can stop early, like those of a
+// ContextSolver. Run calls the context variants once the input is parsed.
+type ContextParser interface {
+	Parse(input []string) (interface{}, error)
+	SolveParsed1Context(ctx context.Context, parsed interface{}) (Answer, error)
+	SolveParsed2Context(ctx context.Context, parsed interface{}) (Answer, error)
+}
+
+// CancellableParsed adapts a ContextParser to the Solver interface. Run
+// recognizes the returned Solver as both a Parser and a ContextParser, so
+// it parses once and can cancel either part.
+func CancellableParsed(p ContextParser) Solver {
+	return cancellableParsed{p}
+}
+
+type cancellableParsed struct {
+	ContextParser
+}
+
+func (c cancellableParsed) Solve1(input []string) (Answer, error) {
+	return SolvePart(c, 1, input)
+}
+
+func (c cancellableParsed) Solve2(input []string) (Answer, error) {
+	return SolvePart(c, 2, input)
+}
+
+func (c cancellableParsed) SolveParsed1(parsed interface{}) (Answer, error) {
+	return c.SolveParsed1Context(context.Background(), parsed)
+}
+
+func (c cancellableParsed) SolveParsed2(parsed interface{}) (Answer, error) {
+	return c.SolveParsed2Context(context.Background(), parsed)
+}
+
+// Flags registers the flags of the wrapped ContextParser, if it has any.
+func (c cancellableParsed) Flags(fs *flag.FlagSet) {
+	if f, ok := c.ContextParser.(Flagger); ok {
+		f.Flags(fs)
+	}
+}
+
+// solveInput runs the selected part of solver, or both when part is 0,
+// against the lines of one input file. Solvers implementing Parser get an
+// extra execution, for part 0, that times the parse. Only the parts of a
+// ContextSolver or ContextParser can be cancelled.
+func solveInput(lines []string, solver Solver, run runner, part int) []execution {
+	p, ok := solver.(Parser)
+	if !ok {
+		if cs, ok := solver.(ContextSolver); ok {
+			return runParts(
+				run,
+				part,
+				func(ctx context.Context) (Answer, error) { return cs.Solve1Context(ctx, lines) },
+				func(ctx context.Context) (Answer, error) { return cs.Solve2Context(ctx, lines) },
+			)
+		}
+		return runParts(
+			run,
+			part,
+			func(context.Context) (Answer, error) { return solver.Solve1(lines) },
+			func(context.Context) (Answer, error) { return solver.Solve2(lines) },
+		)
+	}
+
+	var v interface{}
+	parse := run(0, func(context.Context) (Answer, error) {
+		var err error
+		v, err = p.Parse(lines)
+		return nil, err
+	})
+	if parse.err != nil {
+		err := fmt.Errorf("parse: %w", parse.err)
+		skip := func(part int, _ partFunc) execution { return execution{part: part, err: err} }
+		return append([]execution{parse}, runParts(skip, part, nil, nil)...)
+	}
+
+	solve1 := func(context.Context) (Answer, error) { return p.SolveParsed1(v) }
+	solve2 := func(context.Context) (Answer, error) { return p.SolveParsed2(v) }
+	if cp, ok := solver.(ContextParser); ok {
+		solve1 = func(ctx context.Context) (Answer, error) { return cp.SolveParsed1Context(ctx, v) }
+		solve2 = func(ctx context.Context) (Answer, error) { return cp.SolveParsed2Context(ctx, v) }
+	}
+	return append([]execution{parse}, runParts(run, part, solve1, solve2)...)
+}
+
+// runParts runs part 1, part 2, or both when part is 0.
+func runParts(run runner, part int, solve1, solve2 partFunc) []execution {
+	var executions []execution
+	if part != 2 {
+		executions = append(executions, run(1, solve1))
+	}
+	if part != 1 {
+		executions = append(executions, run(2, solve2))
+	}
+	return executions
+}
+
+// SolvePart runs a single part of solver against input, parsing it first
+// if the solver is a Parser.
+func SolvePart(solver Solver, part int, input []string) (Answer, error) {
+	if part != 1 && part != 2 {
+		return nil, fmt.Errorf("invalid part %d", part)
+	}
+
+	p, ok := solver.(Parser)
+	if !ok {
+		if part == 1 {
+			return solver.Solve1(input)
+		}
+		return solver.Solve2(input)
+	}
+
+	v, err := p.Parse(input)
+	if err != nil {
+		return nil, fmt.Errorf("parse: %w", err)
+	}
+	if part == 1 {
+		return p.SolveParsed1(v)
+	}
+	return p.SolveParsed2(v)
+}
@@ -0,0 +1,171 @@
+package aoc
+
+// Point2 is a position or offset on a plane. Like a Grid, y grows
+// downwards, so Up is negative y.
+type Point2 struct {
+	X, Y int
+}
+
+// The four directions one step from a point.
+var (
+	Up    = Point2{0, -1}
+	Right = Point2{1, 0}
+	Down  = Point2{0, 1}
+	Left  = Point2{-1, 0}
+)
+
+var (
+	// Directions4 are the orthogonal steps, clockwise from Up.
+	Directions4 = []Point2{Up, Right, Down, Left}
+
+	// Directions8 are the orthogonal and diagonal steps, clockwise from Up.
+	Directions8 = []Point2{
+		Up, Up.Add(Right), Right, Down.Add(Right),
+		Down, Down.Add(Left), Left, Up.Add(Left),
+	}
+)
+
+func (p Point2) Add(q Point2) Point2 {
+	return Point2{p.X + q.X, p.Y + q.Y}
+}
+
+func (p Point2) Sub(q Point2) Point2 {
+	return Point2{p.X - q.X, p.Y - q.Y}
+}
+
+func (p Point2) Scale(k int) Point2 {
+	return Point2{p.X * k, p.Y * k}
+}
+
+// Manhattan returns the number of orthogonal steps between p and q.
+func (p Point2) Manhattan(q Point2) int {
+	return AbsDiff(p.X, q.X) + AbsDiff(p.Y, q.Y)
+}
+
+// Chebyshev returns the number of steps between p and q when diagonal
+// steps are allowed.
+func (p Point2) Chebyshev(q Point2) int {
+	return Max([]int{AbsDiff(p.X, q.X), AbsDiff(p.Y, q.Y)})
+}
+
+// RotateClockwise turns p a quarter turn clockwise about the origin, so
+// Up becomes Right.
+func (p Point2) RotateClockwise() Point2 {
+	return Point2{-p.Y, p.X}
+}
+
+// RotateCounterClockwise turns p a quarter turn counterclockwise about
+// the origin, so Up becomes Left.
+func (p Point2) RotateCounterClockwise() Point2 {
+	return Point2{p.Y, -p.X}
+}
+
+// Point3 is a position or offset in space.
+type Point3 struct {
+	X, Y, Z int
+}
+
+func (p Point3) Add(q Point3) Point3 {
+	return Point3{p.X + q.X, p.Y + q.Y, p.Z + q.Z}
+}
+
+func (p Point3) Sub(q Point3) Point3 {
+	return Point3{p.X - q.X, p.Y - q.Y, p.Z - q.Z}
+}
+
+func (p Point3) Scale(k int) Point3 {
+	return Point3{p.X * k, p.Y * k, p.Z * k}
+}
+
+// Manhattan returns the number of orthogonal steps between p and q.
+func (p Point3) Manhattan(q Point3) int {
+	return AbsDiff(p.X, q.X) + AbsDiff(p.Y, q.Y) + AbsDiff(p.Z, q.Z)
+}
+
+// Chebyshev returns the number of steps between p and q when diagonal
+// steps are allowed.
+func (p Point3) Chebyshev(q Point3) int {
+	return Max([]int{AbsDiff(p.X, q.X), AbsDiff(p.Y, q.Y), AbsDiff(p.Z, q.Z)})
+}
+
+func (p Point3) coords() [3]int {
+	return [3]int{p.X, p.Y, p.Z}
+}
+
+// Rotation is a turn of space about the origin by some number of quarter
+// turns about each axis, as a matrix.
+type Rotation [3][3]int
+
+// Identity is the rotation that leaves every point in place.
+var Identity = Rotation{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+
+// Rotations are the 24 ways to orient something in space with its axes
+// lined up with the original ones, starting with Identity. Trying each of
+// them lines up points seen by scanners facing different ways.
+var Rotations = rotations()
+
+func rotations() []Rotation {
+	perms := [][3]int{{0, 1, 2}, {0, 2, 1}, {1, 0, 2}, {1, 2, 0}, {2, 0, 1}, {2, 1, 0}}
+	signs := []int{1, -1}
+
+	var rs []Rotation
+	for _, perm := range perms {
+		for _, sx := range signs {
+			for _, sy := range signs {
+				for _, sz := range signs {
+					var r Rotation
+					r[0][perm[0]] = sx
+					r[1][perm[1]] = sy
+					r[2][perm[2]] = sz
+					// The rest are mirror images.
+					if r.determinant() == 1 {
+						rs = append(rs, r)
+					}
+				}
+			}
+		}
+	}
+	return rs
+}
+
+func (r Rotation) determinant() int {
+	return r[0][0]*(r[1][1]*r[2][2]-r[1][2]*r[2][1]) -
+		r[0][1]*(r[1][0]*r[2][2]-r[1][2]*r[2][0]) +
+		r[0][2]*(r[1][0]*r[2][1]-r[1][1]*r[2][0])
+}
+
+// Apply returns p turned by r.
+func (r Rotation) Apply(p Point3) Point3 {
+	c := p.coords()
+	var out [3]int
+	for i := range r {
+		for j := range c {
+			out[i] += r[i][j] * c[j]
+		}
+	}
+	return Point3{out[0], out[1], out[2]}
+}
+
+// Then returns the rotation that turns by r and then by next.
+func (r Rotation) Then(next Rotation) Rotation {
+	var out Rotation
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			for k := 0; k < 3; k++ {
+				out[i][j] += next[i][k] * r[k][j]
+			}
+		}
+	}
+	return out
+}
+
+// Inverse returns the rotation that undoes r.
+func (r Rotation) Inverse() Rotation {
+	var out Rotation
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			out[i][j] = r[j][i]
+		}
+	}
+	return out
+}
@@ -0,0 +1,98 @@
+package aoc
+
+import "testing"
+
+func TestPoint2(t *testing.T) {
+	p, q := Point2{1, 2}, Point2{4, -2}
+
+	if got, want := p.Add(q), (Point2{5, 0}); got != want {
+		t.Errorf("got sum %v, want %v", got, want)
+	}
+	if got, want := p.Sub(q), (Point2{-3, 4}); got != want {
+		t.Errorf("got difference %v, want %v", got, want)
+	}
+	if got, want := p.Scale(-3), (Point2{-3, -6}); got != want {
+		t.Errorf("got scaled %v, want %v", got, want)
+	}
+	if got := p.Manhattan(q); got != 7 {
+		t.Errorf("got Manhattan distance %d, want 7", got)
+	}
+	if got := p.Chebyshev(q); got != 4 {
+		t.Errorf("got Chebyshev distance %d, want 4", got)
+	}
+}
+
+func TestPoint2Rotation(t *testing.T) {
+	for i, d := range Directions4 {
+		next := Directions4[(i+1)%len(Directions4)]
+		if got := d.RotateClockwise(); got != next {
+			t.Errorf("%v turned clockwise is %v, want %v", d, got, next)
+		}
+		if got := next.RotateCounterClockwise(); got != d {
+			t.Errorf("%v turned counterclockwise is %v, want %v", next, got, d)
+		}
+	}
+	if got := Up.RotateClockwise(); got != Right {
+		t.Errorf("Up turned clockwise is %v, want Right", got)
+	}
+}
+
+func TestPoint3(t *testing.T) {
+	p, q := Point3{1, 2, 3}, Point3{-1, 5, 3}
+
+	if got, want := p.Add(q), (Point3{0, 7, 6}); got != want {
+		t.Errorf("got sum %v, want %v", got, want)
+	}
+	if got, want := p.Sub(q), (Point3{2, -3, 0}); got != want {
+		t.Errorf("got difference %v, want %v", got, want)
+	}
+	if got, want := p.Scale(2), (Point3{2, 4, 6}); got != want {
+		t.Errorf("got scaled %v, want %v", got, want)
+	}
+	if got := p.Manhattan(q); got != 5 {
+		t.Errorf("got Manhattan distance %d, want 5", got)
+	}
+	if got := p.Chebyshev(q); got != 3 {
+		t.Errorf("got Chebyshev distance %d, want 3", got)
+	}
+}
+
+func TestRotations(t *testing.T) {
+	if len(Rotations) != 24 {
+		t.Fatalf("got %d rotations, want 24", len(Rotations))
+	}
+	if Rotations[0] != Identity {
+		t.Errorf("got first rotation %v, want Identity", Rotations[0])
+	}
+
+	// A point with distinct coordinates ends up somewhere different under
+	// each rotation.
+	p := Point3{1, 2, 3}
+	seen := map[Point3]bool{}
+	for _, r := range Rotations {
+		if d := r.determinant(); d != 1 {
+			t.Errorf("rotation %v has determinant %d, want 1", r, d)
+		}
+		if inv := r.Then(r.Inverse()); inv != Identity {
+			t.Errorf("rotation %v then its inverse is %v, want Identity", r, inv)
+		}
+		if got := r.Inverse().Apply(r.Apply(p)); got != p {
+			t.Errorf("rotation %v then its inverse moves %v to %v", r, p, got)
+		}
+		seen[r.Apply(p)] = true
+	}
+	if len(seen) != 24 {
+		t.Errorf("got %d distinct orientations of %v, want 24", len(seen), p)
+	}
+}
+
+func TestRotationThen(t *testing.T) {
+	p := Point3{1, 2, 3}
+	for _, r := range Rotations {
+		for _, next := range Rotations {
+			if got, want := r.Then(next).Apply(p), next.Apply(r.Apply(p)); got != want {
+				t.Fatalf("%v then %v moves %v to %v, want %v", r, next, p, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package aoc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"runtime/debug"
+)
+
+// panicError is the error reported for a part that panicked.
+type panicError struct {
+	value interface{}
+	stack []byte
+}
+
+func (e *panicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.value)
+}
+
+// protect turns a panic in f into a *panicError carrying the stack trace.
+func protect(f partFunc) partFunc {
+	return func(ctx context.Context) (answer Answer, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				answer, err = nil, &panicError{
+					value: r,
+					stack: panicStack(),
+				}
+			}
+		}()
+		return f(ctx)
+	}
+}
+
+// panicStack is the stack trace of a recovered panic, starting from the
+// panic itself rather than from the deferred function recovering it.
+func panicStack() []byte {
+	stack := debug.Stack()
+	if i := bytes.Index(stack, []byte("\npanic(")); i >= 0 {
+		return stack[i+1:]
+	}
+	return stack
+}
+
+// printPanics writes the stack trace of every execution that panicked,
+// along with the input file that caused it.
+func printPanics(w io.Writer, executions []execution) {
+	for _, e := range executions {
+		// A panic while parsing is reported for the parse alone, rather
+		// than again for each part that wraps it.
+		p, ok := e.err.(*panicError)
+		if !ok {
+			continue
+		}
+
+		where := "parse"
+		if e.part > 0 {
+			where = fmt.Sprintf("part %d", e.part)
+		}
+		fmt.Fprintf(w, "\n%s of %s: %s\n%s", where, e.input, p, p.stack)
+	}
+}
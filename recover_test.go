@@ -0,0 +1,107 @@
+package aoc
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// panicky panics in part 1, indexing past the end of its input.
+type panicky struct{}
+
+func (panicky) Solve1(input []string) (Answer, error) {
+	depths := make([]int, len(input))
+	return depths[len(input)], nil
+}
+
+func (panicky) Solve2(input []string) (Answer, error) {
+	n := new(big.Int).Lsh(big.NewInt(1), 100)
+	return n, nil
+}
+
+// panickyParser panics while parsing its input.
+type panickyParser struct{}
+
+func (panickyParser) Parse(input []string) (interface{}, error) {
+	var m map[string]int
+	m["depth"]++
+	return m, nil
+}
+
+func (panickyParser) SolveParsed1(parsed interface{}) (Answer, error) { return 1, nil }
+func (panickyParser) SolveParsed2(parsed interface{}) (Answer, error) { return 2, nil }
+
+// runPanicking runs solver against a one-line input file, returning its
+// executions and the panics it printed.
+func runPanicking(t *testing.T, solver Solver) ([]execution, string) {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "input.txt"), []byte("199\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	p := Puzzle{Year: 2021, Day: 1, Title: "Test", Solver: solver, Dir: dir}
+	executions, err := runPuzzle(Options{Input: "input.txt"}, p)
+	if err == nil {
+		t.Error("got no error from a solver that panicked")
+	}
+
+	var out bytes.Buffer
+	printPanics(&out, executions)
+	return executions, out.String()
+}
+
+func TestPanicInPart(t *testing.T) {
+	executions, printed := runPanicking(t, panicky{})
+
+	parts := map[int]execution{}
+	for _, e := range executions {
+		parts[e.part] = e
+	}
+
+	e := parts[1]
+	var p *panicError
+	if !errors.As(e.err, &p) {
+		t.Fatalf("part 1: got error %v, want a panic", e.err)
+	}
+	if e.status != fail {
+		t.Errorf("part 1: got status %s, want FAIL", e.status)
+	}
+	if !strings.Contains(p.Error(), "index out of range [1] with length 1") {
+		t.Errorf("part 1: got error %q, want the index out of range", p)
+	}
+	if !strings.Contains(string(p.stack), "panicky.Solve1") {
+		t.Errorf("part 1: stack doesn't mention Solve1:\n%s", p.stack)
+	}
+
+	if e := parts[2]; e.err != nil || FormatAnswer(e.solution) != "1267650600228229401496703205376" {
+		t.Errorf("part 2: got answer %v error %v, want 2^100", e.solution, e.err)
+	}
+
+	if !strings.Contains(printed, "part 1 of "+e.input+": panic:") {
+		t.Errorf("printed panic doesn't name part 1 of %s:\n%s", e.input, printed)
+	}
+	if !strings.Contains(printed, "panicky.Solve1") {
+		t.Errorf("printed panic has no stack:\n%s", printed)
+	}
+}
+
+func TestPanicInParse(t *testing.T) {
+	executions, printed := runPanicking(t, Parsed(panickyParser{}))
+
+	for _, e := range executions {
+		if e.err == nil {
+			t.Errorf("part %d: got no error after the parse panicked", e.part)
+		}
+	}
+	if n := strings.Count(printed, "panic:"); n != 1 {
+		t.Errorf("printed %d panics, want 1:\n%s", n, printed)
+	}
+	if !strings.Contains(printed, "\nparse of ") {
+		t.Errorf("printed panic isn't for the parse:\n%s", printed)
+	}
+}
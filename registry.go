@@ -0,0 +1,103 @@
+package aoc
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+)
+
+// Puzzle is a registered solver for a single day of Advent of Code.
+type Puzzle struct {
+	Year   int
+	Day    int
+	Title  string
+	Solver Solver
+
+	// Dir is the directory holding the day's source and input files.
+	Dir string
+}
+
+func (p Puzzle) String() string {
+	return fmt.Sprintf("%d Day %d: %s", p.Year, p.Day, p.Title)
+}
+
+// Run runs the puzzle's solver against opts.Input, resolved relative
+// to the puzzle's directory. It returns an error if any part failed.
+func (p Puzzle) Run(opts Options) error {
+	return RunPuzzles(opts, p)
+}
+
+// resolve returns the path of an input relative to the puzzle's directory.
+func (p Puzzle) resolve(input string) string {
+	if p.Dir == "" || input == Stdin || filepath.IsAbs(input) {
+		return input
+	}
+	return filepath.Join(p.relDir(), input)
+}
+
+// relDir is the puzzle's directory relative to the working directory,
+// when that is possible, to keep printed input paths short.
+func (p Puzzle) relDir() string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return p.Dir
+	}
+	rel, err := filepath.Rel(wd, p.Dir)
+	if err != nil {
+		return p.Dir
+	}
+	return rel
+}
+
+type puzzleKey struct {
+	year int
+	day  int
+}
+
+var registry = map[puzzleKey]Puzzle{}
+
+// Register makes a solver available to the aoc command. It is intended to
+// be called from the init function of each day's package, and panics if
+// the same year and day is registered twice.
+func Register(year, day int, title string, solver Solver) {
+	key := puzzleKey{year, day}
+	if existing, ok := registry[key]; ok {
+		panic(fmt.Sprintf("aoc: Register called twice for %s", existing))
+	}
+
+	var dir string
+	if _, file, _, ok := runtime.Caller(1); ok {
+		dir = filepath.Dir(file)
+	}
+
+	registry[key] = Puzzle{
+		Year:   year,
+		Day:    day,
+		Title:  title,
+		Solver: solver,
+		Dir:    dir,
+	}
+}
+
+// Lookup returns the puzzle registered for the given year and day.
+func Lookup(year, day int) (Puzzle, bool) {
+	p, ok := registry[puzzleKey{year, day}]
+	return p, ok
+}
+
+// Puzzles returns every registered puzzle, ordered by year and day.
+func Puzzles() []Puzzle {
+	var puzzles []Puzzle
+	for _, p := range registry {
+		puzzles = append(puzzles, p)
+	}
+	sort.Slice(puzzles, func(i, j int) bool {
+		if puzzles[i].Year != puzzles[j].Year {
+			return puzzles[i].Year < puzzles[j].Year
+		}
+		return puzzles[i].Day < puzzles[j].Day
+	})
+	return puzzles
+}
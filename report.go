@@ -0,0 +1,238 @@
+package aoc
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Result is the outcome of running one part of a puzzle against one input
+// file, in a form suitable for machine-readable output.
+type Result struct {
+	Year  int    `json:"year"`
+	Day   int    `json:"day"`
+	Input string `json:"input"`
+
+	// Part is 1 or 2, or 0 for the time spent parsing the input or for an
+	// input that could not be read.
+	Part int `json:"part"`
+
+	Answer   string        `json:"answer"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration_ns"`
+	Status   string        `json:"status"`
+}
+
+func newResult(p Puzzle, e execution) Result {
+	r := Result{
+		Year:     p.Year,
+		Day:      p.Day,
+		Input:    e.input,
+		Part:     e.part,
+		Answer:   FormatAnswer(e.solution),
+		Duration: e.elapsed,
+		Status:   e.status.String(),
+	}
+	if e.err != nil {
+		r.Error = e.err.Error()
+	}
+	if e.part == 0 && !e.unread {
+		r.Status = ""
+	}
+	return r
+}
+
+func newResults(p Puzzle, executions []execution) []Result {
+	results := make([]Result, len(executions))
+	for i, e := range executions {
+		results[i] = newResult(p, e)
+	}
+	return results
+}
+
+// reporter writes the executions of each puzzle in some output format.
+type reporter interface {
+	report(p Puzzle, executions []execution) error
+	flush() error
+}
+
+func newReporter(format string, w io.Writer) (reporter, error) {
+	switch format {
+	case "", "text":
+		return textReporter{w}, nil
+	case "json":
+		return jsonReporter{json.NewEncoder(w)}, nil
+	case "csv":
+		return &csvReporter{w: csv.NewWriter(w)}, nil
+	case "markdown":
+		return &markdownReporter{w: w}, nil
+	default:
+		return nil, fmt.Errorf("unknown output format %q", format)
+	}
+}
+
+// textReporter writes results for people to read: a line per part for a
+// single input file, or a table when there are several.
+type textReporter struct {
+	w io.Writer
+}
+
+func (r textReporter) report(p Puzzle, executions []execution) error {
+	// Unreadable inputs are already reported as errors.
+	var read []execution
+	for _, e := range executions {
+		if !e.unread {
+			read = append(read, e)
+		}
+	}
+	if len(read) == 0 {
+		return nil
+	}
+	executions = read
+
+	if p.Title != "" {
+		fmt.Fprintf(r.w, "\n== %s ==\n", p)
+	}
+
+	if executions[0].input != executions[len(executions)-1].input {
+		return printTable(r.w, executions)
+	}
+
+	fmt.Fprintf(r.w, "\nInput: %s\n", executions[0].input)
+	for _, e := range executions {
+		if _, err := fmt.Fprintln(r.w, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r textReporter) flush() error {
+	return nil
+}
+
+// printTable writes one row per input file and part.
+func printTable(w io.Writer, executions []execution) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "\nINPUT\tPART\tANSWER\tTIME\tSTATUS")
+	for _, e := range executions {
+		if e.part == 0 {
+			var output interface{} = ""
+			if e.err != nil {
+				output = e.err
+			}
+			fmt.Fprintf(tw, "%s\tparse\t%v\t%s\t\n", e.input, output, e.timing())
+			continue
+		}
+		fmt.Fprintf(
+			tw,
+			"%s\t%d\t%v\t%s\t%s\n",
+			e.input,
+			e.part,
+			SingleLine(e.output()),
+			e.timing(),
+			e.status,
+		)
+	}
+	return tw.Flush()
+}
+
+// jsonReporter writes one JSON object per line for each result.
+type jsonReporter struct {
+	enc *json.Encoder
+}
+
+func (r jsonReporter) report(p Puzzle, executions []execution) error {
+	for _, e := range executions {
+		if err := r.enc.Encode(newResult(p, e)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r jsonReporter) flush() error {
+	return nil
+}
+
+var resultColumns = []string{"year", "day", "input", "part", "answer", "error", "duration_ns", "status"}
+
+func (r Result) fields() []string {
+	return []string{
+		strconv.Itoa(r.Year),
+		strconv.Itoa(r.Day),
+		r.Input,
+		strconv.Itoa(r.Part),
+		r.Answer,
+		r.Error,
+		strconv.FormatInt(r.Duration.Nanoseconds(), 10),
+		r.Status,
+	}
+}
+
+// csvReporter writes a header row followed by one row per result.
+type csvReporter struct {
+	w      *csv.Writer
+	header bool
+}
+
+func (r *csvReporter) report(p Puzzle, executions []execution) error {
+	if !r.header {
+		r.w.Write(resultColumns)
+		r.header = true
+	}
+	for _, e := range executions {
+		r.w.Write(newResult(p, e).fields())
+	}
+	r.w.Flush()
+	return r.w.Error()
+}
+
+func (r *csvReporter) flush() error {
+	r.w.Flush()
+	return r.w.Error()
+}
+
+// markdownReporter writes a single Markdown table covering every puzzle.
+type markdownReporter struct {
+	w      io.Writer
+	header bool
+}
+
+func (r *markdownReporter) report(p Puzzle, executions []execution) error {
+	if !r.header {
+		rule := make([]string, len(resultColumns))
+		for i := range rule {
+			rule[i] = "---"
+		}
+		r.writeRow(resultColumns)
+		r.writeRow(rule)
+		r.header = true
+	}
+	for _, e := range executions {
+		if err := r.writeRow(newResult(p, e).fields()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *markdownReporter) writeRow(cells []string) error {
+	escaped := make([]string, len(cells))
+	for i, cell := range cells {
+		cell = strings.ReplaceAll(cell, "|", `\|`)
+		cell = strings.ReplaceAll(cell, "\n", "<br>")
+		escaped[i] = cell
+	}
+	_, err := fmt.Fprintf(r.w, "| %s |\n", strings.Join(escaped, " | "))
+	return err
+}
+
+func (r *markdownReporter) flush() error {
+	return nil
+}
@@ -0,0 +1,133 @@
+// Package search finds shortest paths through graphs of puzzle states.
+//
+// States can be anything comparable, like a point on a grid or a struct
+// describing a whole puzzle position. The graph is never built up front:
+// the caller supplies a function returning the neighbors of each state as
+// it is reached. The slice it returns is not kept, so it may be reused
+// between calls.
+package search
+
+import (
+	"github.com/bclarkx2/aoc/ds"
+)
+
+// Edge is a step to a neighboring state, at some cost.
+type Edge[S comparable] struct {
+	To   S
+	Cost int
+}
+
+// Dijkstra finds the cheapest path from start to a state satisfying goal,
+// where neighbors returns the edges out of each state. Costs must not be
+// negative. It returns the total cost and the path, including start and
+// the goal, or false if no goal can be reached.
+func Dijkstra[S comparable](start S, goal func(S) bool, neighbors func(S) []Edge[S]) (int, []S, bool) {
+	return AStar(start, goal, neighbors, func(S) int { return 0 })
+}
+
+// AStar is like Dijkstra, but explores the states that heuristic estimates
+// to be closest to a goal first. The heuristic must never overestimate the
+// remaining cost, or the path found may not be the cheapest. A heuristic
+// that also never drops by more than the cost of an edge explores each
+// state once; others may explore states again as cheaper ways to them turn
+// up.
+func AStar[S comparable](start S, goal func(S) bool, neighbors func(S) []Edge[S], heuristic func(S) int) (int, []S, bool) {
+	visits := map[S]*visit[S]{start: {}}
+
+	frontier := ds.NewPriorityQueue(func(a, b item[S]) bool {
+		return a.priority < b.priority
+	})
+	frontier.Push(item[S]{start, heuristic(start)})
+
+	for frontier.Len() > 0 {
+		it, _ := frontier.Pop()
+		current := it.state
+		v := visits[current]
+
+		// A state is queued again each time a cheaper way to it is found,
+		// so later copies are stale.
+		if v.done {
+			continue
+		}
+		v.done = true
+
+		if goal(current) {
+			return v.cost, path(visits, start, current), true
+		}
+
+		for _, e := range neighbors(current) {
+			proposed := v.cost + e.Cost
+			next, ok := visits[e.To]
+			if !ok {
+				next = &visit[S]{}
+				visits[e.To] = next
+			} else if proposed >= next.cost {
+				continue
+			}
+			next.cost = proposed
+			next.previous = current
+			// An explored state is explored again from its cheaper cost.
+			next.done = false
+			frontier.Push(item[S]{e.To, proposed + heuristic(e.To)})
+		}
+	}
+
+	return 0, nil, false
+}
+
+// visit records the cheapest known way to reach a state.
+type visit[S comparable] struct {
+	cost     int
+	previous S
+	done     bool
+}
+
+// BFS finds the path from start to a state satisfying goal with the fewest
+// steps, where neighbors returns the states one step from each state. It
+// returns the number of steps and the path, including start and the goal,
+// or false if no goal can be reached.
+func BFS[S comparable](start S, goal func(S) bool, neighbors func(S) []S) (int, []S, bool) {
+	visits := map[S]*visit[S]{start: {}}
+
+	var frontier ds.Queue[S]
+	frontier.Push(start)
+	for frontier.Len() > 0 {
+		current, _ := frontier.Pop()
+
+		if goal(current) {
+			v := visits[current]
+			return v.cost, path(visits, start, current), true
+		}
+
+		cost := visits[current].cost + 1
+		for _, next := range neighbors(current) {
+			if _, ok := visits[next]; ok {
+				continue
+			}
+			visits[next] = &visit[S]{cost: cost, previous: current}
+			frontier.Push(next)
+		}
+	}
+
+	return 0, nil, false
+}
+
+// path follows the visits back from end to start.
+func path[S comparable](visits map[S]*visit[S], start, end S) []S {
+	p := []S{end}
+	for current := end; current != start; {
+		current = visits[current].previous
+		p = append(p, current)
+	}
+	for i, j := 0, len(p)-1; i < j; i, j = i+1, j-1 {
+		p[i], p[j] = p[j], p[i]
+	}
+	return p
+}
+
+// item is a state waiting in the queue, with the estimated cost of the
+// cheapest path through it.
+type item[S comparable] struct {
+	state    S
+	priority int
+}
@@ -0,0 +1,129 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+)
+
+// graph maps each state to the edges out of it.
+type graph map[string][]Edge[string]
+
+func (g graph) neighbors(s string) []Edge[string] {
+	return g[s]
+}
+
+// steps drops the costs from the edges, for BFS.
+func (g graph) steps(s string) []string {
+	var next []string
+	for _, e := range g[s] {
+		next = append(next, e.To)
+	}
+	return next
+}
+
+func is(goal string) func(string) bool {
+	return func(s string) bool { return s == goal }
+}
+
+func TestSearch(t *testing.T) {
+	// The direct route is the fewest steps but not the cheapest.
+	roads := graph{
+		"S": {{"A", 1}, {"G", 10}},
+		"A": {{"B", 2}, {"S", 1}},
+		"B": {{"G", 3}},
+		"X": {{"S", 1}},
+	}
+
+	// h is admissible but not consistent: it overestimates nothing, but
+	// drops by 4 along the edge from A to C, which costs 1. C is first
+	// reached the expensive way, through B, and must be explored again.
+	detour := graph{
+		"S": {{"A", 1}, {"B", 1}},
+		"A": {{"C", 1}},
+		"B": {{"C", 3}},
+		"C": {{"G", 3}},
+	}
+	h := map[string]int{"A": 4}
+
+	zero := func(string) int { return 0 }
+
+	tests := []struct {
+		name     string
+		search   func() (int, []string, bool)
+		wantCost int
+		wantPath []string
+		wantOK   bool
+	}{
+		{
+			name:     "Dijkstra",
+			search:   func() (int, []string, bool) { return Dijkstra("S", is("G"), roads.neighbors) },
+			wantCost: 6,
+			wantPath: []string{"S", "A", "B", "G"},
+			wantOK:   true,
+		},
+		{
+			name:     "Dijkstra at the goal",
+			search:   func() (int, []string, bool) { return Dijkstra("S", is("S"), roads.neighbors) },
+			wantCost: 0,
+			wantPath: []string{"S"},
+			wantOK:   true,
+		},
+		{
+			name:   "Dijkstra unreachable",
+			search: func() (int, []string, bool) { return Dijkstra("S", is("X"), roads.neighbors) },
+		},
+		{
+			name:     "AStar",
+			search:   func() (int, []string, bool) { return AStar("S", is("G"), roads.neighbors, zero) },
+			wantCost: 6,
+			wantPath: []string{"S", "A", "B", "G"},
+			wantOK:   true,
+		},
+		{
+			name: "AStar with an inconsistent heuristic",
+			search: func() (int, []string, bool) {
+				return AStar("S", is("G"), detour.neighbors, func(s string) int { return h[s] })
+			},
+			wantCost: 5,
+			wantPath: []string{"S", "A", "C", "G"},
+			wantOK:   true,
+		},
+		{
+			name:   "AStar unreachable",
+			search: func() (int, []string, bool) { return AStar("S", is("X"), roads.neighbors, zero) },
+		},
+		{
+			name:     "BFS",
+			search:   func() (int, []string, bool) { return BFS("S", is("G"), roads.steps) },
+			wantCost: 1,
+			wantPath: []string{"S", "G"},
+			wantOK:   true,
+		},
+		{
+			name:     "BFS two steps",
+			search:   func() (int, []string, bool) { return BFS("S", is("B"), roads.steps) },
+			wantCost: 2,
+			wantPath: []string{"S", "A", "B"},
+			wantOK:   true,
+		},
+		{
+			name:   "BFS unreachable",
+			search: func() (int, []string, bool) { return BFS("S", is("X"), roads.steps) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cost, path, ok := tt.search()
+			if ok != tt.wantOK {
+				t.Fatalf("got ok %t, want %t", ok, tt.wantOK)
+			}
+			if cost != tt.wantCost {
+				t.Errorf("got cost %d, want %d", cost, tt.wantCost)
+			}
+			if !reflect.DeepEqual(path, tt.wantPath) {
+				t.Errorf("got path %v, want %v", path, tt.wantPath)
+			}
+		})
+	}
+}